@@ -4,5 +4,7 @@ go 1.14
 
 require (
 	github.com/golang/protobuf v1.4.0-rc.4
+	go.starlark.net v0.0.0-20200619143648-50ca820fafb9
+	google.golang.org/grpc v1.29.1
 	google.golang.org/protobuf v1.20.1
 )
@@ -0,0 +1,204 @@
+// Package cli implements the bl3 command line tool.
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cfi2017/bl3-save-core/pkg/assets"
+	"github.com/cfi2017/bl3-save-core/pkg/shared"
+)
+
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+// command is a bl3 subcommand. run receives the arguments following the command name.
+type command struct {
+	name        string
+	usage       string
+	description string
+	run         func(e *env, args []string) error
+}
+
+// env is the environment a command runs in.
+type env struct {
+	stdin        io.Reader
+	stdout       io.Writer
+	stderr       io.Writer
+	assetsLoaded bool
+	assetsErr    error
+}
+
+// usageError is returned by commands that were called with invalid arguments.
+type usageError struct {
+	msg string
+}
+
+func (u usageError) Error() string {
+	return u.msg
+}
+
+func usagef(format string, args ...interface{}) error {
+	return usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+var commands []*command
+
+func init() {
+	commands = []*command{
+		{name: "decode", usage: "decode [-platform pc] [-type auto] [-format proto] [-o file] <save>", description: "decode a save to JSON", run: runDecode},
+		{name: "encode", usage: "encode [-platform pc] [-type auto] [-format proto] -o <save> <json>", description: "encode JSON to a save", run: runEncode},
+		{name: "info", usage: "info [-platform pc] [-type auto] <save>", description: "show the header and a summary of a save", run: runInfo},
+		{name: "schema", usage: "schema [-o file] character|profile", description: "print the JSON Schema of proto format documents", run: runSchema},
+		{name: "verify", usage: "verify [-platform pc] [-type auto] [-strict] <save>...", description: "check that saves survive a decode and encode unchanged", run: runVerify},
+		{name: "diff", usage: "diff [-platform pc] [-type auto] <old> <new>", description: "show the differences between two saves", run: runDiff},
+		{name: "patch", usage: "patch [-platform pc] [-type auto] -o <dir> | -w <patch.json> <save>...", description: "apply a JSON Patch to saves", run: runPatch},
+		{name: "script", usage: "script [-platform pc] [-type auto] [-timeout 30s] -o <dir> | -w <script.star> <save>...", description: "run a Starlark script against saves", run: runScript},
+		{name: "query", usage: "query [-platform pc] [-type auto] [-json] <query> <save>...", description: "find items in saves", run: runQuery},
+		{name: "item", usage: "item decode <serial>... | item encode [-code] <json>", description: "decode or encode item serials", run: runItem},
+		{name: "convert", usage: "convert -from <platform> -to <platform> [-type auto] <in> <out>", description: "convert a save between platforms", run: runConvert},
+		{name: "serve", usage: "serve -dir <saves> [-addr localhost:8080] [-platform pc] [-web dir] [-open]", description: "serve saves and the editor API over HTTP", run: runServe},
+		{name: "grpc", usage: "grpc [-addr localhost:9090]", description: "serve the save service over gRPC", run: runGRPC},
+	}
+}
+
+/*
+Run runs the bl3 command line tool with the given arguments, excluding the program name, and returns its exit code.
+Output is written to stdout, errors and warnings to stderr.
+*/
+func Run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("bl3", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	assetsDir := fs.String("assets", ".", "directory containing the item database and catalog")
+	fs.Usage = func() { usage(stderr, fs) }
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if fs.NArg() == 0 {
+		usage(stderr, fs)
+		return exitUsage
+	}
+	cmd := findCommand(fs.Arg(0))
+	if cmd == nil {
+		fmt.Fprintf(stderr, "bl3: unknown command %s\n", fs.Arg(0))
+		usage(stderr, fs)
+		return exitUsage
+	}
+
+	e := &env{stdin: stdin, stdout: stdout, stderr: stderr}
+	e.loadAssets(*assetsDir)
+	err := shared.Catch(func() error {
+		return cmd.run(e, fs.Args()[1:])
+	})
+	if err == nil {
+		return exitOK
+	}
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+	fmt.Fprintf(stderr, "bl3 %s: %v\n", cmd.name, err)
+	var u usageError
+	if errors.As(err, &u) {
+		fmt.Fprintf(stderr, "usage: bl3 %s\n", cmd.usage)
+		return exitUsage
+	}
+	return exitError
+}
+
+func usage(w io.Writer, fs *flag.FlagSet) {
+	fmt.Fprintln(w, "usage: bl3 [-assets dir] <command> [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", c.name, c.description)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "flags:")
+	fs.PrintDefaults()
+}
+
+func findCommand(name string) *command {
+	for _, c := range commands {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// flags creates the flag set of a subcommand. Parse errors are reported as usage errors.
+func (e *env) flags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	return fs
+}
+
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return usageError{msg: err.Error()}
+	}
+	return nil
+}
+
+/*
+loadAssets sets up the item database and registers the catalog (catalog.json, see assets.Catalog) from the given directory.
+A missing database isn't fatal, commands that need it report the error once they do. The catalog is optional.
+*/
+func (e *env) loadAssets(dir string) {
+	if err := assets.LoadCatalog(filepath.Join(dir, "catalog.json")); err != nil && !os.IsNotExist(err) {
+		fmt.Fprintf(e.stderr, "bl3: ignoring catalog: %v\n", err)
+	}
+	loader := &assets.StaticFileAssetLoader{Pwd: dir}
+	if err := loader.Load(); err != nil {
+		e.assetsErr = err
+		return
+	}
+	assets.DefaultAssetLoader = loader
+	e.assetsLoaded = true
+}
+
+// requireAssets returns an error if the item database couldn't be loaded.
+func (e *env) requireAssets() error {
+	if !e.assetsLoaded {
+		return fmt.Errorf("item database not available: %v", e.assetsErr)
+	}
+	return nil
+}
+
+// open opens a file for reading, - is stdin.
+func (e *env) open(path string) (io.ReadCloser, error) {
+	if path == "-" {
+		return ioutil.NopCloser(e.stdin), nil
+	}
+	return os.Open(path)
+}
+
+// create opens a file for writing, - or an empty path is stdout.
+func (e *env) create(path string) (io.WriteCloser, error) {
+	if path == "" || path == "-" {
+		return nopWriteCloser{e.stdout}, nil
+	}
+	return os.Create(path)
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
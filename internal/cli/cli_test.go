@@ -0,0 +1,176 @@
+package cli
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cfi2017/bl3-save-core/pkg/character"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+	"github.com/cfi2017/bl3-save-core/pkg/shared"
+)
+
+func testHeader() shared.SavFile {
+	return shared.SavFile{
+		SgVersion:  2,
+		PkgVersion: 516,
+		BuildId:    "OAK-PATCHDIESEL1-280",
+		FmtVersion: 3,
+		CustomFmtData: []shared.CustomFormatData{
+			{Guid: "0123456789abcdef0123456789abcdef", Entry: 1},
+		},
+		SgType: "OakSaveGame",
+	}
+}
+
+func writeTestCharacter(t *testing.T, dir string) string {
+	c := &pb.Character{
+		SaveGameId:             3,
+		PreferredCharacterName: "Test",
+		InventoryItems:         []*pb.OakInventoryItemSaveGameData{{ItemSerialNumber: []byte{0x03, 0, 0, 0, 0, 1, 2}}},
+		GbxZoneMapFodSaveGameData: &pb.GbxZoneMapFODSaveGameData{
+			LevelData: []*pb.GbxZoneMapFODSavedLevelData{{LevelName: "Prologue_P", DiscoveryPercentage: float32(math.Inf(1))}},
+		},
+	}
+	path, err := character.WriteFile(dir, testHeader(), c, "pc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func run(args ...string) (int, string, string) {
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	code := Run(append([]string{"-assets", os.TempDir()}, args...), strings.NewReader(""), stdout, stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestDecodeEncode(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bl3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := writeTestCharacter(t, dir)
+	jsonPath := filepath.Join(dir, "3.json")
+	if code, _, stderr := run("decode", "-format", "editor", "-o", jsonPath, path); code != exitOK {
+		t.Fatalf("decode failed: %s", stderr)
+	}
+	out := filepath.Join(dir, "out.sav")
+	if code, _, stderr := run("encode", "-format", "editor", "-o", out, jsonPath); code != exitOK {
+		t.Fatalf("encode failed: %s", stderr)
+	}
+	original, _ := ioutil.ReadFile(path)
+	encoded, _ := ioutil.ReadFile(out)
+	if !bytes.Equal(original, encoded) {
+		t.Fatal("save changed in round trip")
+	}
+	if code, stdout, _ := run("info", path); code != exitOK || !strings.Contains(stdout, "Test") {
+		t.Fatalf("unexpected info output: %s", stdout)
+	}
+	protoPath := filepath.Join(dir, "3.proto.json")
+	if code, _, stderr := run("decode", "-o", protoPath, path); code != exitOK {
+		t.Fatalf("proto decode failed: %s", stderr)
+	}
+	if code, _, stderr := run("encode", "-o", out, protoPath); code != exitOK {
+		t.Fatalf("proto encode failed: %s", stderr)
+	}
+	if encoded, _ := ioutil.ReadFile(out); !bytes.Equal(original, encoded) {
+		t.Fatal("save changed in proto round trip")
+	}
+	if code, stdout, _ := run("verify", "-strict", path); code != exitOK || !strings.Contains(stdout, "identical") {
+		t.Fatalf("unexpected verify output: %s", stdout)
+	}
+	if code, stdout, _ := run("diff", path, out); code != exitOK || !strings.Contains(stdout, "no differences") {
+		t.Fatalf("unexpected diff output: %s", stdout)
+	}
+	patchPath := filepath.Join(dir, "patch.json")
+	patched := filepath.Join(dir, "patched")
+	if err := ioutil.WriteFile(patchPath, []byte(`[{"op": "replace", "path": "/preferredCharacterName", "value": "Patched"}]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(patched, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if code, _, stderr := run("patch", "-o", patched, patchPath, path); code != exitOK {
+		t.Fatalf("patch failed: %s", stderr)
+	}
+	if code, stdout, _ := run("info", filepath.Join(patched, filepath.Base(path))); code != exitOK || !strings.Contains(stdout, "Patched") {
+		t.Fatalf("patch not applied: %s", stdout)
+	}
+	scriptPath := filepath.Join(dir, "rename.star")
+	if err := ioutil.WriteFile(scriptPath, []byte(`save.data["preferredCharacterName"] = "Scripted"`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if code, _, stderr := run("script", "-w", scriptPath, filepath.Join(patched, filepath.Base(path))); code != exitOK {
+		t.Fatalf("script failed: %s", stderr)
+	}
+	if code, stdout, _ := run("info", filepath.Join(patched, filepath.Base(path))); code != exitOK || !strings.Contains(stdout, "Scripted") {
+		t.Fatalf("script not applied: %s", stdout)
+	}
+	converted := filepath.Join(dir, "ps4.sav")
+	if code, _, stderr := run("convert", "-from", "pc", "-to", "ps4", path, converted); code != exitOK {
+		t.Fatalf("convert failed: %s", stderr)
+	}
+	if code, _, _ := run("info", "-platform", "ps4", converted); code != exitOK {
+		t.Fatal("converted save not readable")
+	}
+}
+
+func TestExitCodes(t *testing.T) {
+	if code, _, _ := run(); code != exitUsage {
+		t.Fatalf("expected usage exit code, got %d", code)
+	}
+	if code, _, _ := run("frobnicate"); code != exitUsage {
+		t.Fatalf("expected usage exit code, got %d", code)
+	}
+	if code, _, _ := run("decode", "-platform", "xbox", "1.sav"); code != exitUsage {
+		t.Fatalf("expected usage exit code, got %d", code)
+	}
+	if code, _, stderr := run("decode", filepath.Join(os.TempDir(), "does-not-exist.sav")); code != exitError || stderr == "" {
+		t.Fatalf("expected error exit code, got %d", code)
+	}
+	if code, _, _ := run("item", "decode", "BL3(AwAAAAA=)"); code != exitError {
+		t.Fatalf("expected error without item database, got %d", code)
+	}
+	if code, _, _ := run("query", "colour=red", "1.sav"); code != exitUsage {
+		t.Fatalf("expected usage exit code for an invalid query, got %d", code)
+	}
+	if code, _, _ := run("query", "level>=65", "1.sav"); code != exitError {
+		t.Fatalf("expected error without item database, got %d", code)
+	}
+}
+
+func TestEditOutputCollision(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bl3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	saves := make([]string, 2)
+	for i, name := range []string{"a", "b"} {
+		if err := os.Mkdir(filepath.Join(dir, name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		saves[i] = writeTestCharacter(t, filepath.Join(dir, name))
+	}
+	out := filepath.Join(dir, "out")
+	if err := os.Mkdir(out, 0755); err != nil {
+		t.Fatal(err)
+	}
+	patchPath := filepath.Join(dir, "patch.json")
+	if err := ioutil.WriteFile(patchPath, []byte(`[]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	code, _, stderr := run("patch", "-o", out, patchPath, saves[0], saves[1])
+	if code != exitError || !strings.Contains(stderr, "would both be written") {
+		t.Fatalf("expected collision error, got %d: %s", code, stderr)
+	}
+	if files, _ := ioutil.ReadDir(out); len(files) != 0 {
+		t.Fatal("saves were written despite the collision")
+	}
+}
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/cfi2017/bl3-save-core/pkg/character"
+	"github.com/cfi2017/bl3-save-core/pkg/diff"
+	"github.com/cfi2017/bl3-save-core/pkg/profile"
+)
+
+func runDiff(e *env, args []string) error {
+	fs := e.flags("diff")
+	platform := fs.String("platform", "pc", "save platform (pc, ps4)")
+	kind := fs.String("type", typeAuto, "save type (auto, character, profile)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return usagef("expected two save files")
+	}
+	// use the type of the first save for both, backups often have different names
+	saveKind, err := saveType(*kind, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	a, err := e.readSave(fs.Arg(0), saveKind, *platform)
+	if err != nil {
+		return err
+	}
+	b, err := e.readSave(fs.Arg(1), saveKind, *platform)
+	if err != nil {
+		return err
+	}
+	changes := diff.Headers(a.Header, b.Header)
+	if a.Profile != nil {
+		changes = append(changes, profile.Diff(a.Profile, b.Profile)...)
+	} else {
+		changes = append(changes, character.Diff(a.Character, b.Character)...)
+	}
+	if len(changes) == 0 {
+		fmt.Fprintln(e.stdout, "no differences")
+	}
+	for _, c := range changes {
+		fmt.Fprintln(e.stdout, c)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package cli
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/cfi2017/bl3-save-core/pkg/rpc"
+	"google.golang.org/grpc"
+)
+
+func runGRPC(e *env, args []string) error {
+	fs := e.flags("grpc")
+	addr := fs.String("addr", "localhost:9090", "address to listen on")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usagef("unexpected arguments")
+	}
+	if !e.assetsLoaded {
+		fmt.Fprintf(e.stderr, "warning: item operations will fail, item database not available: %v\n", e.assetsErr)
+	}
+	l, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	s := grpc.NewServer()
+	rpc.RegisterSaveServiceServer(s, rpc.NewServer())
+	fmt.Fprintf(e.stderr, "serving gRPC on %s\n", l.Addr())
+	return s.Serve(l)
+}
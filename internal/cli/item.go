@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cfi2017/bl3-save-core/pkg/item"
+	"github.com/cfi2017/bl3-save-core/pkg/profile"
+)
+
+func runItem(e *env, args []string) error {
+	if len(args) == 0 {
+		return usagef("expected decode or encode")
+	}
+	switch args[0] {
+	case "decode":
+		return runItemDecode(e, args[1:])
+	case "encode":
+		return runItemEncode(e, args[1:])
+	}
+	return usagef("unknown item command %s", args[0])
+}
+
+func runItemDecode(e *env, args []string) error {
+	fs := e.flags("item decode")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return usagef("expected at least one serial")
+	}
+	if err := e.requireAssets(); err != nil {
+		return err
+	}
+	items := make([]item.Item, 0, fs.NArg())
+	for _, s := range fs.Args() {
+		serial, err := profile.DecodeMailSerial(s)
+		if err != nil {
+			return fmt.Errorf("invalid serial %s: %v", s, err)
+		}
+		i, err := item.Deserialize(serial)
+		if err != nil {
+			return fmt.Errorf("couldn't decode %s: %v", s, err)
+		}
+		items = append(items, i)
+	}
+	if len(items) == 1 {
+		return e.writeJSON("", items[0])
+	}
+	return e.writeJSON("", items)
+}
+
+func runItemEncode(e *env, args []string) error {
+	fs := e.flags("item encode")
+	code := fs.Bool("code", false, "print serials as BL3(...) codes instead of base64")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usagef("expected exactly one JSON file")
+	}
+	data, err := e.readAll(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	var items []item.Item
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "[") {
+		err = json.Unmarshal(data, &items)
+	} else {
+		items = make([]item.Item, 1)
+		err = json.Unmarshal(data, &items[0])
+	}
+	if err != nil {
+		return err
+	}
+	if err := e.checkItems(items); err != nil {
+		return err
+	}
+	for _, i := range items {
+		serial, err := item.Reserialize(i)
+		if err != nil {
+			return err
+		}
+		if *code {
+			fmt.Fprintln(e.stdout, profile.EncodeMailSerial(serial))
+		} else {
+			fmt.Fprintln(e.stdout, base64.StdEncoding.EncodeToString(serial))
+		}
+	}
+	return nil
+}
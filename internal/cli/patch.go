@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+
+	"github.com/cfi2017/bl3-save-core/pkg/document"
+)
+
+func runPatch(e *env, args []string) error {
+	fs := e.flags("patch")
+	opts := editFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() < 2 {
+		return usagef("expected a patch file and at least one save file")
+	}
+	if err := opts.check(); err != nil {
+		return err
+	}
+	data, err := e.readAll(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	patch, err := document.ParsePatch(data)
+	if err != nil {
+		return fmt.Errorf("invalid patch: %v", err)
+	}
+	return e.editSaves(opts, fs.Args()[1:], func(s *saveFile) error {
+		return e.checkUnknownFields(document.ApplyPatch(s.message(), patch))
+	})
+}
+
+// editOptions are the flags of commands that edit saves in bulk.
+type editOptions struct {
+	platform *string
+	kind     *string
+	out      *string
+	inPlace  *bool
+}
+
+func editFlags(fs *flag.FlagSet) editOptions {
+	return editOptions{
+		platform: fs.String("platform", "pc", "save platform (pc, ps4)"),
+		kind:     fs.String("type", typeAuto, "save type (auto, character, profile)"),
+		out:      fs.String("o", "", "output directory for the edited saves"),
+		inPlace:  fs.Bool("w", false, "overwrite the saves instead of writing them to the output directory"),
+	}
+}
+
+func (o editOptions) check() error {
+	if (*o.out == "") == !*o.inPlace {
+		return usagef("expected either an output directory or -w")
+	}
+	return nil
+}
+
+/*
+editSaves applies an edit to every save and writes the results.
+All saves are edited before any is written, so an edit that fails for one of them doesn't leave a partial result.
+Saves are written to the output directory by base name, saves that would overwrite each other are rejected.
+*/
+func (e *env) editSaves(opts editOptions, saves []string, edit func(s *saveFile) error) error {
+	outputs := make([]string, len(saves))
+	sources := make(map[string]string)
+	for i, path := range saves {
+		outputs[i] = path
+		if !*opts.inPlace {
+			outputs[i] = filepath.Join(*opts.out, filepath.Base(path))
+		}
+		if other, ok := sources[outputs[i]]; ok {
+			return fmt.Errorf("%s and %s would both be written to %s", other, path, outputs[i])
+		}
+		sources[outputs[i]] = path
+	}
+	edited := make([]*saveFile, len(saves))
+	for i, path := range saves {
+		s, err := e.readSave(path, *opts.kind, *opts.platform)
+		if err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+		if err := edit(s); err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+		edited[i] = s
+	}
+	for i, path := range outputs {
+		if err := e.writeSave(path, edited[i], *opts.platform); err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+		fmt.Fprintf(e.stdout, "wrote %s\n", path)
+	}
+	return nil
+}
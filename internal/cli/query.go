@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cfi2017/bl3-save-core/pkg/item"
+	"github.com/cfi2017/bl3-save-core/pkg/profile"
+	"github.com/cfi2017/bl3-save-core/pkg/query"
+)
+
+// queryResult is a match as written by query -json.
+type queryResult struct {
+	Source   string         `json:"source"`
+	Location query.Location `json:"location"`
+	Index    int            `json:"index"`
+	Slot     string         `json:"slot,omitempty"`
+	Serial   string         `json:"serial"`
+	Item     item.Item      `json:"item"`
+}
+
+func runQuery(e *env, args []string) error {
+	fs := e.flags("query")
+	platform := fs.String("platform", "pc", "save platform (pc, ps4)")
+	kind := fs.String("type", typeAuto, "save type (auto, character, profile)")
+	asJSON := fs.Bool("json", false, "write the matches as JSON")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() < 2 {
+		return usagef("expected a query and at least one save file")
+	}
+	q, err := query.Parse(fs.Arg(0))
+	if err != nil {
+		return usagef("invalid query: %v", err)
+	}
+	if err := e.requireAssets(); err != nil {
+		return err
+	}
+	var results []query.Result
+	for _, path := range fs.Args()[1:] {
+		s, err := e.readSave(path, *kind, *platform)
+		if err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+		var matches []query.Result
+		if s.Profile != nil {
+			matches, err = query.Profile(q, path, s.Profile)
+		} else {
+			matches, err = query.Character(q, path, s.Character)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+		results = append(results, matches...)
+	}
+	if *asJSON {
+		list := make([]queryResult, len(results))
+		for i, r := range results {
+			list[i] = queryResult{Source: r.Source, Location: r.Location, Index: r.Index, Slot: r.Slot,
+				Serial: profile.EncodeMailSerial(r.Serial), Item: r.Item}
+		}
+		return e.writeJSON("", list)
+	}
+	w := tabwriter.NewWriter(e.stdout, 0, 4, 2, ' ', 0)
+	for _, r := range results {
+		location := fmt.Sprintf("%s[%d]", r.Location, r.Index)
+		if r.Slot != "" {
+			location += " " + r.Slot
+		}
+		fmt.Fprintf(w, "%s\t%s\tlevel %d\t%s\t%s\t%s\n", r.Source, location, r.Item.Level,
+			shortName(r.Item.Manufacturer), shortName(r.Item.Balance), profile.EncodeMailSerial(r.Serial))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(e.stderr, "%d matches\n", len(results))
+	return nil
+}
+
+// shortName returns the object name of an asset path, e.g. Vladof for /Game/Gear/Manufacturers/_Design/Vladof.Vladof.
+func shortName(path string) string {
+	return path[strings.LastIndexAny(path, "/.")+1:]
+}
@@ -0,0 +1,556 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cfi2017/bl3-save-core/pkg/character"
+	"github.com/cfi2017/bl3-save-core/pkg/document"
+	"github.com/cfi2017/bl3-save-core/pkg/item"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+	"github.com/cfi2017/bl3-save-core/pkg/profile"
+	"github.com/cfi2017/bl3-save-core/pkg/shared"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	typeAuto      = "auto"
+	typeCharacter = "character"
+	typeProfile   = "profile"
+)
+
+const (
+	formatEditor = "editor"
+	formatProto  = "proto"
+)
+
+var platforms = []string{"pc", "ps4"}
+
+// itemsDocument holds the decoded inventory of a character, in the same form as used by the web editor.
+type itemsDocument struct {
+	Items    []item.Item                         `json:"items"`
+	Equipped []*pb.EquippedInventorySaveGameData `json:"equipped"`
+	Active   []int32                             `json:"active"`
+}
+
+// characterDocument is the JSON form of a character save.
+type characterDocument struct {
+	Save      shared.SavFile          `json:"save"`
+	Character *pb.Character           `json:"character"`
+	Items     itemsDocument           `json:"items"`
+	Unknown   []document.UnknownField `json:"unknown,omitempty"`
+}
+
+// profileDocument is the JSON form of a profile save.
+type profileDocument struct {
+	Save     shared.SavFile          `json:"save"`
+	Profile  *pb.Profile             `json:"profile"`
+	Items    []item.Item             `json:"items"`
+	LostLoot []item.Item             `json:"lostLoot"`
+	Unknown  []document.UnknownField `json:"unknown,omitempty"`
+}
+
+// saveFile is a decoded character or profile save. Exactly one of Character and Profile is set.
+type saveFile struct {
+	Header    shared.SavFile
+	Character *pb.Character
+	Profile   *pb.Profile
+}
+
+func (s *saveFile) message() proto.Message {
+	if s.Profile != nil {
+		return s.Profile
+	}
+	return s.Character
+}
+
+func runDecode(e *env, args []string) error {
+	fs := e.flags("decode")
+	platform := fs.String("platform", "pc", "save platform (pc, ps4)")
+	kind := fs.String("type", typeAuto, "save type (auto, character, profile)")
+	format := fs.String("format", formatProto, "JSON format (proto, editor)")
+	out := fs.String("o", "", "output file, defaults to stdout")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usagef("expected exactly one save file")
+	}
+	if err := checkFormat(*format); err != nil {
+		return err
+	}
+	s, err := e.readSave(fs.Arg(0), *kind, *platform)
+	if err != nil {
+		return err
+	}
+	if *format == formatProto {
+		data, err := protoDocument(s)
+		if err != nil {
+			return err
+		}
+		return e.writeFile(*out, append(data, '\n'))
+	}
+	doc, err := e.document(s)
+	if err != nil {
+		return err
+	}
+	return e.writeJSON(*out, doc)
+}
+
+func runEncode(e *env, args []string) error {
+	fs := e.flags("encode")
+	platform := fs.String("platform", "pc", "save platform (pc, ps4)")
+	kind := fs.String("type", typeAuto, "save type (auto, character, profile)")
+	format := fs.String("format", formatProto, "JSON format (proto, editor)")
+	out := fs.String("o", "", "output save file")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usagef("expected exactly one JSON file")
+	}
+	if *out == "" {
+		return usagef("missing output file")
+	}
+	if err := checkFormat(*format); err != nil {
+		return err
+	}
+	data, err := e.readAll(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	s, err := e.parseFormat(data, *kind, *format)
+	if err != nil {
+		return err
+	}
+	return e.writeSave(*out, s, *platform)
+}
+
+func runInfo(e *env, args []string) error {
+	fs := e.flags("info")
+	platform := fs.String("platform", "pc", "save platform (pc, ps4)")
+	kind := fs.String("type", typeAuto, "save type (auto, character, profile)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usagef("expected exactly one save file")
+	}
+	s, err := e.readSave(fs.Arg(0), *kind, *platform)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(e.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "save game version:\t%d\n", s.Header.SgVersion)
+	fmt.Fprintf(w, "package version:\t%d\n", s.Header.PkgVersion)
+	fmt.Fprintf(w, "engine version:\t%d.%d.%d-%d\n", s.Header.EngineMajorVersion, s.Header.EngineMinorVersion,
+		s.Header.EnginePatchVersion, s.Header.EngineBuildVersion)
+	fmt.Fprintf(w, "build id:\t%s\n", s.Header.BuildId)
+	fmt.Fprintf(w, "save game type:\t%s\n", s.Header.SgType)
+	if c := s.Character; c != nil {
+		class := "unknown"
+		if cl, ok := character.GetClass(c); ok {
+			class = fmt.Sprintf("%s (%s)", cl.Character, cl.Name)
+		}
+		fmt.Fprintf(w, "name:\t%s\n", c.PreferredCharacterName)
+		fmt.Fprintf(w, "class:\t%s\n", class)
+		fmt.Fprintf(w, "save game id:\t%d (%s)\n", c.SaveGameId, character.SaveFileName(c.SaveGameId))
+		fmt.Fprintf(w, "save game guid:\t%s\n", c.SaveGameGuid)
+		fmt.Fprintf(w, "experience:\t%d\n", c.ExperiencePoints)
+		fmt.Fprintf(w, "playthroughs:\t%d\n", character.Playthroughs(c))
+		fmt.Fprintf(w, "mayhem level:\t%d\n", c.MayhemLevel)
+		fmt.Fprintf(w, "time played:\t%dh%02dm\n", c.TimePlayedSeconds/3600, c.TimePlayedSeconds/60%60)
+		fmt.Fprintf(w, "backpack:\t%d/%s\n", character.BackpackUsage(c), formatSize(character.BackpackSize(c)))
+		fmt.Fprintf(w, "equipped:\t%d\n", len(character.GetEquipped(c)))
+	}
+	if p := s.Profile; p != nil {
+		fmt.Fprintf(w, "bank:\t%d/%s\n", len(p.BankInventoryList), formatSize(profile.BankSize(p)))
+		fmt.Fprintf(w, "lost loot:\t%d/%s\n", len(p.LostLootInventoryList), formatSize(profile.LostLootSize(p)))
+		fmt.Fprintf(w, "guardian rank:\t%d\n", profile.GetGuardianRank(p).Rank)
+		fmt.Fprintf(w, "mail:\t%d (%d unread)\n", len(p.MailGuids), len(p.UnreadMailGuids))
+	}
+	unknown := document.FindUnknownFields(s.message())
+	if len(unknown) == 0 {
+		fmt.Fprintf(w, "unknown fields:\tnone\n")
+	}
+	for _, u := range unknown {
+		path := u.Path
+		if path == "" {
+			path = "(root)"
+		}
+		numbers, err := u.FieldNumbers()
+		if err != nil {
+			fmt.Fprintf(w, "unknown fields:\t%s: %v\n", path, err)
+			continue
+		}
+		fmt.Fprintf(w, "unknown fields:\t%s: %s\n", path, strings.Trim(fmt.Sprint(numbers), "[]"))
+	}
+	return w.Flush()
+}
+
+// formatSize formats the number of slots of a backpack, bank or lost loot machine, which is -1 if it isn't known.
+func formatSize(size int) string {
+	if size < 0 {
+		return "?"
+	}
+	return strconv.Itoa(size)
+}
+
+func runConvert(e *env, args []string) error {
+	fs := e.flags("convert")
+	from := fs.String("from", "", "source platform (pc, ps4)")
+	to := fs.String("to", "", "target platform (pc, ps4)")
+	kind := fs.String("type", typeAuto, "save type (auto, character, profile)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return usagef("expected input and output file")
+	}
+	if *from == "" || *to == "" {
+		return usagef("missing source or target platform")
+	}
+	if err := checkPlatform(*to); err != nil {
+		return err
+	}
+	s, err := e.readSave(fs.Arg(0), *kind, *from)
+	if err != nil {
+		return err
+	}
+	return e.writeSave(fs.Arg(1), s, *to)
+}
+
+// readSave reads and decrypts a save file. Save types are guessed from the file name when set to auto.
+func (e *env) readSave(path, kind, platform string) (*saveFile, error) {
+	if err := checkPlatform(platform); err != nil {
+		return nil, err
+	}
+	kind, err := saveType(kind, path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := e.readAll(path)
+	if err != nil {
+		return nil, err
+	}
+	return decodeSave(data, kind, platform)
+}
+
+func decodeSave(data []byte, kind, platform string) (s *saveFile, err error) {
+	err = shared.Catch(func() error {
+		s = &saveFile{}
+		if kind == typeProfile {
+			header, p, err := profile.Deserialize(bytes.NewReader(data), platform)
+			s.Header, s.Profile = header, &p
+			return err
+		}
+		header, c, err := character.Deserialize(bytes.NewReader(data), platform)
+		s.Header, s.Character = header, &c
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s save: %v", kind, err)
+	}
+	return s, nil
+}
+
+// writeSave encrypts and writes a save file.
+func (e *env) writeSave(path string, s *saveFile, platform string) error {
+	if err := checkPlatform(platform); err != nil {
+		return err
+	}
+	data, err := encodeSave(s, platform)
+	if err != nil {
+		return err
+	}
+	return e.writeFile(path, data)
+}
+
+func encodeSave(s *saveFile, platform string) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	err := shared.Catch(func() error {
+		if s.Profile != nil {
+			return profile.Encode(buf, s.Header, s.Profile, platform)
+		}
+		return character.Encode(buf, s.Header, s.Character, platform)
+	})
+	return buf.Bytes(), err
+}
+
+/*
+document converts a save into its JSON document.
+Items are only decoded if the item database is available, otherwise they are kept as serials.
+*/
+func (e *env) document(s *saveFile) (interface{}, error) {
+	if !e.assetsLoaded {
+		fmt.Fprintf(e.stderr, "warning: items are not decoded, item database not available: %v\n", e.assetsErr)
+	}
+	unknown := document.FindUnknownFields(s.message())
+	if len(unknown) > 0 {
+		fmt.Fprintf(e.stderr, "warning: save contains fields unknown to this version in %d messages, they are kept in the unknown list\n", len(unknown))
+	}
+	if p := s.Profile; p != nil {
+		doc := profileDocument{Save: s.Header, Profile: p, Unknown: unknown}
+		doc.Items, doc.LostLoot = rawItems(p.BankInventoryList), rawItems(p.LostLootInventoryList)
+		if e.assetsLoaded {
+			if bank, err := profile.GetBank(p); err != nil {
+				fmt.Fprintf(e.stderr, "warning: items are not decoded: %v\n", err)
+			} else {
+				doc.Items, doc.LostLoot = bank.Items, bank.LostLoot
+			}
+		}
+		return doc, nil
+	}
+	c := proto.Clone(s.Character).(*pb.Character)
+	setDiscoveryPlaceholders(c)
+	doc := characterDocument{Save: s.Header, Character: c, Unknown: unknown, Items: itemsDocument{
+		Items:    make([]item.Item, len(c.InventoryItems)),
+		Equipped: c.EquippedInventoryList,
+		Active:   c.ActiveWeaponList,
+	}}
+	for i, data := range c.InventoryItems {
+		doc.Items.Items[i] = item.Item{Wrapper: data}
+	}
+	if e.assetsLoaded {
+		if items, err := character.GetItems(c); err != nil {
+			fmt.Fprintf(e.stderr, "warning: items are not decoded: %v\n", err)
+		} else {
+			doc.Items.Items = items
+		}
+	}
+	return doc, nil
+}
+
+// parseDocument converts a JSON document back into a save, serializing its items.
+func (e *env) parseDocument(data []byte, kind string) (*saveFile, error) {
+	if kind == typeAuto {
+		var keys map[string]json.RawMessage
+		if err := json.Unmarshal(data, &keys); err != nil {
+			return nil, err
+		}
+		kind = typeCharacter
+		if _, ok := keys["profile"]; ok {
+			kind = typeProfile
+		}
+	}
+	if kind == typeProfile {
+		var doc profileDocument
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		if doc.Profile == nil {
+			return nil, fmt.Errorf("document contains no profile")
+		}
+		if err := e.checkItems(append(append([]item.Item{}, doc.Items...), doc.LostLoot...)); err != nil {
+			return nil, err
+		}
+		bank := profile.Bank{Items: doc.Items, LostLoot: doc.LostLoot}
+		if doc.LostLoot == nil {
+			// documents without lost loot keep the profile's lost loot as is
+			bank.LostLoot = rawItems(doc.Profile.LostLootInventoryList)
+		}
+		if err := bank.Apply(doc.Profile); err != nil {
+			return nil, err
+		}
+		if err := e.checkUnknownFields(document.RestoreUnknownFields(doc.Profile, doc.Unknown)); err != nil {
+			return nil, err
+		}
+		return &saveFile{Header: doc.Save, Profile: doc.Profile}, nil
+	}
+	if kind != typeCharacter {
+		return nil, usagef("unknown save type %s", kind)
+	}
+	var doc characterDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Character == nil {
+		return nil, fmt.Errorf("document contains no character")
+	}
+	if err := e.checkItems(doc.Items.Items); err != nil {
+		return nil, err
+	}
+	if err := character.SetItems(doc.Character, doc.Items.Items); err != nil {
+		return nil, err
+	}
+	doc.Character.EquippedInventoryList = doc.Items.Equipped
+	doc.Character.ActiveWeaponList = doc.Items.Active
+	restoreDiscoveryPlaceholders(doc.Character)
+	if err := e.checkUnknownFields(document.RestoreUnknownFields(doc.Character, doc.Unknown)); err != nil {
+		return nil, err
+	}
+	return &saveFile{Header: doc.Save, Character: doc.Character}, nil
+}
+
+// discoveryPlaceholder stands for infinite discovery percentages in editor documents, as JSON can't represent infinity.
+// It's the value used by the web editor, proto documents don't need it.
+const discoveryPlaceholder = -1
+
+func setDiscoveryPlaceholders(c *pb.Character) {
+	if c.GbxZoneMapFodSaveGameData == nil {
+		return
+	}
+	for _, d := range c.GbxZoneMapFodSaveGameData.LevelData {
+		if math.IsInf(float64(d.DiscoveryPercentage), 1) {
+			d.DiscoveryPercentage = discoveryPlaceholder
+		}
+	}
+}
+
+// restoreDiscoveryPlaceholders turns placeholders back into infinity, so editor documents round trip without changes.
+func restoreDiscoveryPlaceholders(c *pb.Character) {
+	if c.GbxZoneMapFodSaveGameData == nil {
+		return
+	}
+	for _, d := range c.GbxZoneMapFodSaveGameData.LevelData {
+		if d.DiscoveryPercentage == discoveryPlaceholder {
+			d.DiscoveryPercentage = float32(math.Inf(1))
+		}
+	}
+}
+
+// protoDocument converts a save into its protojson document, see package document.
+func protoDocument(s *saveFile) ([]byte, error) {
+	if s.Profile != nil {
+		return document.MarshalProfile(s.Header, s.Profile)
+	}
+	return document.MarshalCharacter(s.Header, s.Character)
+}
+
+// parseFormat converts a JSON document of the given format back into a save.
+func (e *env) parseFormat(data []byte, kind, format string) (*saveFile, error) {
+	if format == formatProto {
+		return e.parseProtoDocument(data, kind)
+	}
+	return e.parseDocument(data, kind)
+}
+
+// parseProtoDocument converts a protojson document back into a save.
+func (e *env) parseProtoDocument(data []byte, kind string) (*saveFile, error) {
+	if kind == typeAuto {
+		isProfile, err := document.IsProfile(data)
+		if err != nil {
+			return nil, err
+		}
+		kind = typeCharacter
+		if isProfile {
+			kind = typeProfile
+		}
+	}
+	switch kind {
+	case typeProfile:
+		header, p, err := document.UnmarshalProfile(data)
+		if err := e.checkUnknownFields(err); err != nil {
+			return nil, err
+		}
+		return &saveFile{Header: header, Profile: p}, nil
+	case typeCharacter:
+		header, c, err := document.UnmarshalCharacter(data)
+		if err := e.checkUnknownFields(err); err != nil {
+			return nil, err
+		}
+		return &saveFile{Header: header, Character: c}, nil
+	}
+	return nil, usagef("unknown save type %s", kind)
+}
+
+// checkUnknownFields turns unknown fields of removed messages into a warning, they were removed along with their message.
+func (e *env) checkUnknownFields(err error) error {
+	var missing *document.MissingFieldsError
+	if errors.As(err, &missing) {
+		fmt.Fprintf(e.stderr, "warning: dropped unknown fields of removed messages: %s\n", strings.Join(missing.Paths, ", "))
+		return nil
+	}
+	return err
+}
+
+// checkItems makes sure the item database is available if any decoded items need to be serialized.
+func (e *env) checkItems(items []item.Item) error {
+	for _, i := range items {
+		if i.Balance != "" {
+			return e.requireAssets()
+		}
+	}
+	return nil
+}
+
+func (e *env) writeJSON(path string, v interface{}) error {
+	w, err := e.create(path)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// writeFile writes data to a file, - or an empty path is stdout.
+func (e *env) writeFile(path string, data []byte) error {
+	w, err := e.create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+func rawItems(list [][]byte) []item.Item {
+	items := make([]item.Item, len(list))
+	for i, serial := range list {
+		items[i] = item.Item{Wrapper: &pb.OakInventoryItemSaveGameData{ItemSerialNumber: serial}}
+	}
+	return items
+}
+
+func saveType(kind, path string) (string, error) {
+	switch kind {
+	case typeCharacter, typeProfile:
+		return kind, nil
+	case typeAuto:
+		if shared.GuessIsProfileSav(strings.ReplaceAll(path, "\\", "/")) {
+			return typeProfile, nil
+		}
+		return typeCharacter, nil
+	}
+	return "", usagef("unknown save type %s", kind)
+}
+
+func checkPlatform(platform string) error {
+	for _, p := range platforms {
+		if p == platform {
+			return nil
+		}
+	}
+	return usagef("unknown platform %s, expected one of %s", platform, strings.Join(platforms, ", "))
+}
+
+func checkFormat(format string) error {
+	if format != formatEditor && format != formatProto {
+		return usagef("unknown format %s, expected editor or proto", format)
+	}
+	return nil
+}
+
+// readAll reads a whole file, - is stdin.
+func (e *env) readAll(path string) ([]byte, error) {
+	r, err := e.open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
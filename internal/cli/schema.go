@@ -0,0 +1,30 @@
+package cli
+
+import (
+	"github.com/cfi2017/bl3-save-core/pkg/document"
+)
+
+func runSchema(e *env, args []string) error {
+	fs := e.flags("schema")
+	out := fs.String("o", "", "output file, defaults to stdout")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usagef("expected exactly one save type")
+	}
+	var data []byte
+	var err error
+	switch fs.Arg(0) {
+	case typeCharacter:
+		data, err = document.CharacterSchema()
+	case typeProfile:
+		data, err = document.ProfileSchema()
+	default:
+		return usagef("unknown save type %s", fs.Arg(0))
+	}
+	if err != nil {
+		return err
+	}
+	return e.writeFile(*out, append(data, '\n'))
+}
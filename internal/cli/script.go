@@ -0,0 +1,31 @@
+package cli
+
+import (
+	"github.com/cfi2017/bl3-save-core/pkg/script"
+)
+
+func runScript(e *env, args []string) error {
+	fs := e.flags("script")
+	opts := editFlags(fs)
+	timeout := fs.Duration("timeout", script.DefaultTimeout, "time a script may run on each save")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() < 2 {
+		return usagef("expected a script and at least one save file")
+	}
+	if err := opts.check(); err != nil {
+		return err
+	}
+	filename := fs.Arg(0)
+	src, err := e.readAll(filename)
+	if err != nil {
+		return err
+	}
+	return e.editSaves(opts, fs.Args()[1:], func(s *saveFile) error {
+		if s.Profile != nil {
+			return script.RunProfile(filename, src, s.Profile, script.Options{Out: e.stdout, Timeout: *timeout})
+		}
+		return script.RunCharacter(filename, src, s.Character, script.Options{Out: e.stdout, Timeout: *timeout})
+	})
+}
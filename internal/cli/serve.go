@@ -0,0 +1,423 @@
+package cli
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cfi2017/bl3-save-core/pkg/item"
+	"github.com/cfi2017/bl3-save-core/pkg/shared"
+)
+
+// maxUploadSize limits the size of request bodies.
+const maxUploadSize = 32 << 20
+
+// saveInfo is an entry of the save listing.
+type saveInfo struct {
+	Name     string    `json:"name"`
+	Type     string    `json:"type"`
+	Size     int64     `json:"size"`
+	Modified time.Time `json:"modified"`
+}
+
+// serialRequest is the request and response body of the item serial endpoints.
+type serialRequest struct {
+	Serial string `json:"serial"`
+	Seed   *int32 `json:"seed,omitempty"`
+}
+
+// server serves the save directory and the wasm equivalent endpoints.
+type server struct {
+	env      *env
+	dir      string
+	platform string
+}
+
+func runServe(e *env, args []string) error {
+	fs := e.flags("serve")
+	addr := fs.String("addr", "localhost:8080", "address to listen on")
+	dir := fs.String("dir", "", "saves directory")
+	platform := fs.String("platform", "pc", "platform of the saves in the directory (pc, ps4)")
+	web := fs.String("web", "", "directory with web editor files to serve at /")
+	open := fs.Bool("open", false, "open the editor in the browser")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *dir == "" {
+		return usagef("missing saves directory")
+	}
+	if err := checkPlatform(*platform); err != nil {
+		return err
+	}
+	if info, err := os.Stat(*dir); err != nil {
+		return err
+	} else if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", *dir)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/api/", newServer(e, *dir, *platform))
+	if *web != "" {
+		mux.Handle("/", http.FileServer(http.Dir(*web)))
+	}
+	l, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("http://%s/", l.Addr())
+	fmt.Fprintf(e.stderr, "serving %s on %s\n", *dir, url)
+	if *open {
+		shared.OpenBrowser(url)
+	}
+	return http.Serve(l, mux)
+}
+
+/*
+newServer creates the API handler:
+
+	GET    /api/saves                 list saves
+	GET    /api/saves/<name>          download a save
+	PUT    /api/saves/<name>          upload a save
+	DELETE /api/saves/<name>          delete a save
+	GET    /api/saves/<name>/json     decode a save
+	PUT    /api/saves/<name>/json     encode a JSON document into a save
+	POST   /api/decode/character      decodeCharacter, save in, JSON out
+	POST   /api/decode/profile        decodeProfile
+	POST   /api/encode/character      encodeCharacter, JSON in, save out
+	POST   /api/encode/profile        encodeProfile
+	POST   /api/items/deserialise     deserialiseItemBase64, {"serial"} in, item out
+	POST   /api/items/serialise       serialiseItemBase64, item in, {"serial"} out
+	POST   /api/items/seed            getSeedFromSerial, {"serial"} in, {"seed"} out
+
+The platform of the decode and encode endpoints defaults to the server's platform and can be set with ?platform=.
+JSON documents use the web editor's format, ?format=proto selects the protojson documents described by the published schema.
+*/
+func newServer(e *env, dir, platform string) http.Handler {
+	s := &server{env: e, dir: dir, platform: platform}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/saves", s.handleList)
+	mux.HandleFunc("/api/saves/", s.handleSave)
+	mux.HandleFunc("/api/decode/", s.handleDecode)
+	mux.HandleFunc("/api/encode/", s.handleEncode)
+	mux.HandleFunc("/api/items/deserialise", s.handleDeserialiseItem)
+	mux.HandleFunc("/api/items/serialise", s.handleSerialiseItem)
+	mux.HandleFunc("/api/items/seed", s.handleSeed)
+	return mux
+}
+
+func (s *server) handleList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+	files, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	saves := make([]saveInfo, 0)
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".sav") {
+			continue
+		}
+		kind, _ := saveType(typeAuto, f.Name())
+		saves = append(saves, saveInfo{Name: f.Name(), Type: kind, Size: f.Size(), Modified: f.ModTime()})
+	}
+	sort.Slice(saves, func(i, j int) bool { return saves[i].Name < saves[j].Name })
+	writeJSON(w, saves)
+}
+
+func (s *server) handleSave(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/api/saves/")
+	asJSON := strings.HasSuffix(name, "/json")
+	name = strings.TrimSuffix(name, "/json")
+	if name == "" || name != filepath.Base(name) || !strings.HasSuffix(name, ".sav") {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid save name %s", name))
+		return
+	}
+	path := filepath.Join(s.dir, name)
+	kind, _ := saveType(typeAuto, name)
+
+	switch {
+	case r.Method == http.MethodGet && asJSON:
+		format, err := requestFormat(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		sav, err := s.env.readSave(path, kind, s.platform)
+		if os.IsNotExist(err) {
+			writeError(w, http.StatusNotFound, err)
+			return
+		} else if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		s.writeDocument(w, sav, format)
+	case r.Method == http.MethodPut && asJSON:
+		format, err := requestFormat(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		data, err := readBody(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		sav, err := s.env.parseFormat(data, kind, format)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		if err := s.env.writeSave(path, sav, s.platform); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet:
+		f, err := os.Open(path)
+		if os.IsNotExist(err) {
+			writeError(w, http.StatusNotFound, err)
+			return
+		} else if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		defer f.Close()
+		info, err := f.Stat()
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+		http.ServeContent(w, r, name, info.ModTime(), f)
+	case r.Method == http.MethodPut:
+		data, err := readBody(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		// only accept files that decode as a save of the directory's platform
+		if _, err := decodeSave(data, kind, s.platform); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		if err := ioutil.WriteFile(path, data, 0644); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodDelete && !asJSON:
+		if err := os.Remove(path); os.IsNotExist(err) {
+			writeError(w, http.StatusNotFound, err)
+			return
+		} else if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+	}
+}
+
+func (s *server) handleDecode(w http.ResponseWriter, r *http.Request) {
+	kind := strings.TrimPrefix(r.URL.Path, "/api/decode/")
+	platform, err := s.requestPlatform(r, kind)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	format, err := requestFormat(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	data, err := readBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	sav, err := decodeSave(data, kind, platform)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	s.writeDocument(w, sav, format)
+}
+
+func (s *server) handleEncode(w http.ResponseWriter, r *http.Request) {
+	kind := strings.TrimPrefix(r.URL.Path, "/api/encode/")
+	platform, err := s.requestPlatform(r, kind)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	format, err := requestFormat(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	data, err := readBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	sav, err := s.env.parseFormat(data, kind, format)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	bs, err := encodeSave(sav, platform)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	_, _ = w.Write(bs)
+}
+
+func (s *server) handleDeserialiseItem(w http.ResponseWriter, r *http.Request) {
+	var req serialRequest
+	if err := s.readItemRequest(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	serial, err := base64.StdEncoding.DecodeString(req.Serial)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	i, err := item.Decode(serial)
+	var dbErr *item.DatabaseError
+	if errors.As(err, &dbErr) {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	} else if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, i)
+}
+
+func (s *server) handleSerialiseItem(w http.ResponseWriter, r *http.Request) {
+	var i item.Item
+	if err := s.readItemRequest(r, &i); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	var serial []byte
+	if err := shared.Catch(func() (err error) {
+		serial, err = item.Reserialize(i)
+		return
+	}); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, serialRequest{Serial: base64.StdEncoding.EncodeToString(serial)})
+}
+
+func (s *server) handleSeed(w http.ResponseWriter, r *http.Request) {
+	var req serialRequest
+	if err := s.readItemRequest(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	serial, err := base64.StdEncoding.DecodeString(req.Serial)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	seed, err := item.GetSeedFromSerial(serial)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, serialRequest{Serial: req.Serial, Seed: &seed})
+}
+
+// readItemRequest checks the request method and the item database and decodes the JSON body into v.
+func (s *server) readItemRequest(r *http.Request, v interface{}) error {
+	if r.Method != http.MethodPost {
+		return fmt.Errorf("method %s not allowed", r.Method)
+	}
+	if err := s.env.requireAssets(); err != nil {
+		return err
+	}
+	data, err := readBody(r)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func (s *server) requestPlatform(r *http.Request, kind string) (string, error) {
+	if r.Method != http.MethodPost {
+		return "", fmt.Errorf("method %s not allowed", r.Method)
+	}
+	if kind != typeCharacter && kind != typeProfile {
+		return "", fmt.Errorf("unknown save type %s", kind)
+	}
+	platform := r.URL.Query().Get("platform")
+	if platform == "" {
+		platform = s.platform
+	}
+	return platform, checkPlatform(platform)
+}
+
+// writeDocument writes the JSON document of a save in the given format.
+func (s *server) writeDocument(w http.ResponseWriter, sav *saveFile, format string) {
+	if format == formatEditor {
+		doc, err := s.env.document(sav)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, doc)
+		return
+	}
+	data, err := protoDocument(sav)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+// requestFormat returns the document format requested with ?format=, the web editor's format by default.
+func requestFormat(r *http.Request) (string, error) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		return formatEditor, nil
+	}
+	return format, checkFormat(format)
+}
+
+func readBody(r *http.Request) ([]byte, error) {
+	defer r.Body.Close()
+	return ioutil.ReadAll(http.MaxBytesReader(nil, r.Body, maxUploadSize))
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
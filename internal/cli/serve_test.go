@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestServer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bl3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := writeTestCharacter(t, dir)
+	original, _ := ioutil.ReadFile(path)
+
+	e := &env{stderr: ioutil.Discard}
+	ts := httptest.NewServer(newServer(e, dir, "pc"))
+	defer ts.Close()
+
+	var saves []saveInfo
+	res, err := http.Get(ts.URL + "/api/saves")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.NewDecoder(res.Body).Decode(&saves); err != nil || len(saves) != 1 || saves[0].Name != "3.sav" {
+		t.Fatalf("unexpected listing %v (%v)", saves, err)
+	}
+
+	res, err = http.Get(ts.URL + "/api/saves/3.sav/json")
+	if err != nil || res.StatusCode != http.StatusOK {
+		t.Fatalf("decode failed: %v", err)
+	}
+	doc, _ := ioutil.ReadAll(res.Body)
+	res, err = http.Post(ts.URL+"/api/encode/character", "application/json", bytes.NewReader(doc))
+	if err != nil || res.StatusCode != http.StatusOK {
+		t.Fatalf("encode failed: %v", err)
+	}
+	encoded, _ := ioutil.ReadAll(res.Body)
+	if !bytes.Equal(encoded, original) {
+		t.Fatal("save changed in round trip")
+	}
+
+	res, err = http.Post(ts.URL+"/api/decode/character?format=proto", "application/octet-stream", bytes.NewReader(original))
+	if err != nil || res.StatusCode != http.StatusOK {
+		t.Fatalf("proto decode failed: %v", err)
+	}
+	doc, _ = ioutil.ReadAll(res.Body)
+	if !bytes.Contains(doc, []byte(`"discoveryPercentage": "Infinity"`)) {
+		t.Fatalf("not a proto document: %s", doc)
+	}
+	res, err = http.Post(ts.URL+"/api/encode/character?format=proto", "application/json", bytes.NewReader(doc))
+	if err != nil || res.StatusCode != http.StatusOK {
+		t.Fatalf("proto encode failed: %v", err)
+	}
+	if encoded, _ := ioutil.ReadAll(res.Body); !bytes.Equal(encoded, original) {
+		t.Fatal("save changed in proto round trip")
+	}
+	if res, err := http.Get(ts.URL + "/api/saves/3.sav/json?format=yaml"); err != nil || res.StatusCode != http.StatusBadRequest {
+		t.Fatal("unknown format accepted")
+	}
+
+	req, _ := http.NewRequest(http.MethodPut, ts.URL+"/api/saves/4.sav", bytes.NewReader(encoded))
+	if res, err := http.DefaultClient.Do(req); err != nil || res.StatusCode != http.StatusNoContent {
+		t.Fatal("upload failed")
+	}
+	req, _ = http.NewRequest(http.MethodPut, ts.URL+"/api/saves/5.sav", bytes.NewReader([]byte("garbage")))
+	if res, err := http.DefaultClient.Do(req); err != nil || res.StatusCode != http.StatusBadRequest {
+		t.Fatal("invalid upload accepted")
+	}
+	if res, err := http.Get(ts.URL + "/api/saves/..%2F3.sav"); err != nil || res.StatusCode == http.StatusOK {
+		t.Fatal("path traversal not rejected")
+	}
+	res, err = http.Get(ts.URL + "/api/saves/4.sav")
+	if err != nil || res.StatusCode != http.StatusOK {
+		t.Fatal("download failed")
+	}
+	if downloaded, _ := ioutil.ReadAll(res.Body); !bytes.Equal(downloaded, original) {
+		t.Fatal("downloaded save differs")
+	}
+}
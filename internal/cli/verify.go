@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/cfi2017/bl3-save-core/pkg/character"
+	"github.com/cfi2017/bl3-save-core/pkg/profile"
+	"github.com/cfi2017/bl3-save-core/pkg/shared"
+)
+
+func runVerify(e *env, args []string) error {
+	fs := e.flags("verify")
+	platform := fs.String("platform", "pc", "save platform (pc, ps4)")
+	kind := fs.String("type", typeAuto, "save type (auto, character, profile)")
+	strict := fs.Bool("strict", false, "fail unless saves are byte identical")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return usagef("expected at least one save file")
+	}
+	if err := checkPlatform(*platform); err != nil {
+		return err
+	}
+	failed := 0
+	for _, path := range fs.Args() {
+		result, err := e.verify(path, *kind, *platform)
+		if err != nil {
+			return err
+		}
+		printVerifyResult(e, path, result)
+		if result.Status == shared.Different || *strict && result.Status != shared.Identical {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d saves changed in round trip", failed, fs.NArg())
+	}
+	return nil
+}
+
+func (e *env) verify(path, kind, platform string) (shared.VerifyResult, error) {
+	kind, err := saveType(kind, path)
+	if err != nil {
+		return shared.VerifyResult{}, err
+	}
+	data, err := e.readAll(path)
+	if err != nil {
+		return shared.VerifyResult{}, err
+	}
+	var result shared.VerifyResult
+	if kind == typeProfile {
+		result, err = profile.Verify(data, platform)
+	} else {
+		result, err = character.Verify(data, platform)
+	}
+	if err != nil {
+		return result, fmt.Errorf("%s: invalid %s save: %v", path, kind, err)
+	}
+	return result, nil
+}
+
+func printVerifyResult(e *env, path string, result shared.VerifyResult) {
+	if result.Status == shared.Identical {
+		fmt.Fprintf(e.stdout, "%s: identical\n", path)
+		return
+	}
+	fmt.Fprintf(e.stdout, "%s: %s, first difference at offset %d, size %d -> %d\n",
+		path, result.Status, result.Offset, result.Size, result.EncodedSize)
+	for _, h := range result.Header {
+		fmt.Fprintf(e.stdout, "  header %s changed\n", h)
+	}
+	for _, f := range result.Fields {
+		change := "changed"
+		if f.Equal {
+			change = "re-encoded, value unchanged"
+		}
+		if f.Offset < 0 {
+			fmt.Fprintf(e.stdout, "  field %s (%d) added, %s\n", f.Name, f.Number, change)
+			continue
+		}
+		fmt.Fprintf(e.stdout, "  field %s (%d) at offset %d %s\n", f.Name, f.Number, f.Offset, change)
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Package testassets provides a small item database for tests of packages that decode items.
+It only contains the assets of a single assault rifle, which is enough to serialize and deserialize items.
+*/
+package testassets
+
+import (
+	"strings"
+
+	"github.com/cfi2017/bl3-save-core/pkg/assets"
+	"github.com/cfi2017/bl3-save-core/pkg/item"
+)
+
+const (
+	Balance      = "/Game/Gear/Weapons/AssaultRifles/Vladof/_Shared/_Design/_Manufacturer/Balance/Balance_AR_VLA_05_Legendary.Balance_AR_VLA_05_Legendary"
+	InvData      = "/Game/Gear/Weapons/AssaultRifles/Vladof/_Shared/_Design/_Manufacturer/AR_VLA.AR_VLA"
+	Manufacturer = "/Game/Gear/Manufacturers/_Design/Vladof.Vladof"
+	// UnknownBalance has no part list in the database, items using it are decoded without parts.
+	UnknownBalance = "/Game/Gear/Weapons/AssaultRifles/Vladof/_Shared/_Design/_Manufacturer/Balance/Balance_AR_VLA_01_Common.Balance_AR_VLA_01_Common"
+	partsKey       = "BPInvPart_AR_VLA_C"
+
+	// Station is a travel station of the test catalog in the first level of assets.Levels.
+	Station = "/Test/FastTravel/Station.Station"
+	// SecondPlaythroughStation is a travel station of the test catalog only available in the second playthrough.
+	SecondPlaythroughStation = "/Test/FastTravel/SecondPlaythroughStation.SecondPlaythroughStation"
+	// EmoteHash and TrinketHash are the hashes of the Siren emote and the weapon trinket of the test catalog.
+	EmoteHash   = 1001
+	TrinketHash = 1002
+	// EchoLog, Room and Decoration are an ECHO log, a crew quarters room and a decoration of the test catalog.
+	EchoLog    = "/Test/EchoLogs/EchoLog_Test.EchoLog_Test"
+	Room       = "/Test/CrewQuarters/Room_Test.Room_Test"
+	Decoration = "/Test/CrewQuarters/Deco_Test.Deco_Test"
+	// VaultCard is the id of the vault card of the test catalog, it has 100 levels of 20000 experience points each.
+	VaultCard = 1
+)
+
+var (
+	Parts = []string{
+		"/Game/Gear/Weapons/AssaultRifles/Vladof/_Shared/_Design/Parts/Body/Part_AR_VLA_Body.Part_AR_VLA_Body",
+		"/Game/Gear/Weapons/AssaultRifles/Vladof/_Shared/_Design/Parts/Barrel/Part_AR_VLA_Barrel_01.Part_AR_VLA_Barrel_01",
+		"/Game/Gear/Weapons/AssaultRifles/Vladof/_Shared/_Design/Parts/Barrel/Part_AR_VLA_Barrel_02.Part_AR_VLA_Barrel_02",
+	}
+	Generics = []string{
+		"/Game/Gear/Weapons/_Shared/_Design/EndGameParts/Character/Gunner/GPart_Gunner_WeaponDamage.GPart_Gunner_WeaponDamage",
+		"/Game/Gear/Weapons/_Shared/_Design/EndGameParts/Character/Siren/GPart_Siren_WeaponDamage.GPart_Siren_WeaponDamage",
+	}
+)
+
+type loader struct {
+	db   assets.PartsDatabase
+	btik map[string]string
+}
+
+func (l loader) GetDB() assets.PartsDatabase {
+	return l.db
+}
+
+func (l loader) GetBtik() map[string]string {
+	return l.btik
+}
+
+func data(values ...string) assets.Data {
+	return assets.Data{Versions: assets.Versions{{Version: 0, Bits: 6}}, Assets: values}
+}
+
+/*
+Load sets the test database as the default database and returns a function restoring the previous one.
+*/
+func Load() (restore func()) {
+	previous := assets.DefaultAssetLoader
+	assets.DefaultAssetLoader = loader{
+		db: assets.PartsDatabase{
+			"InventoryBalanceData":     data(Balance, UnknownBalance),
+			"InventoryData":            data(InvData),
+			"ManufacturerData":         data(Manufacturer),
+			"InventoryGenericPartData": data(Generics...),
+			partsKey:                   data(Parts...),
+		},
+		btik: map[string]string{strings.ToLower(Balance): partsKey},
+	}
+	return func() { assets.DefaultAssetLoader = previous }
+}
+
+// Item returns an item of the test database with the first part and no generics.
+func Item(level int) item.Item {
+	return item.Item{
+		Level:         level,
+		Balance:       Balance,
+		Manufacturer:  Manufacturer,
+		InvData:       InvData,
+		Parts:         Parts[:1],
+		Generics:      []string{},
+		SerialVersion: 4,
+	}
+}
+
+// Serial serializes an item with the test database. It panics if the item can't be serialized.
+func Serial(i item.Item, seed int32) []byte {
+	serial, err := item.Serialize(i, seed)
+	if err != nil {
+		panic(err)
+	}
+	return serial
+}
+
+/*
+Catalog registers SDU capacities and levels, a vehicle, travel stations, cosmetics, slot challenges, an ECHO log,
+crew quarters items and a vault card for tests and returns a function restoring the previous catalog.
+The data is made up, the catalog only needs to be consistent for the tests using it.
+*/
+func Catalog() (restore func()) {
+	sdus, vehicles := append([]assets.SDU{}, assets.SDUs...), append([]assets.Vehicle{}, assets.Vehicles...)
+	stations, cosmetics := append([]assets.TravelStation{}, assets.TravelStations...), append([]assets.Cosmetic{}, assets.Cosmetics...)
+	challenges, logs := append([]assets.Challenge{}, assets.Challenges...), append([]assets.EchoLog{}, assets.EchoLogs...)
+	rooms, decorations := append([]assets.CrewQuartersItem{}, assets.CrewQuartersRooms...), append([]assets.CrewQuartersItem{}, assets.CrewQuartersDecorations...)
+	cards := append([]assets.VaultCard{}, assets.VaultCards...)
+	assets.RegisterCatalog(assets.Catalog{SDUs: []assets.SDU{
+		{Name: assets.SDUBackpack, Path: "/Game/Pickups/SDU/SDU_Backpack.SDU_Backpack", MaxLevel: 8, Base: 15, PerLevel: 3},
+		{Name: assets.SDUBank, Path: "/Game/Pickups/SDU/SDU_Bank.SDU_Bank", MaxLevel: 23, Base: 20, PerLevel: 6, Profile: true},
+		{Name: assets.SDULostLoot, Path: "/Game/Pickups/SDU/SDU_LostLoot.SDU_LostLoot", MaxLevel: 8, Base: 10, PerLevel: 2, Profile: true},
+		{Name: "Assault Rifle", Path: "/Game/Pickups/SDU/SDU_AssaultRifle.SDU_AssaultRifle", MaxLevel: 10},
+		{Name: "Pistol", Path: "/Game/Pickups/SDU/SDU_Pistol.SDU_Pistol", MaxLevel: 10},
+		{Name: "SMG", Path: "/Game/Pickups/SDU/SDU_SMG.SDU_SMG", MaxLevel: 10},
+		{Name: "Shotgun", Path: "/Game/Pickups/SDU/SDU_Shotgun.SDU_Shotgun", MaxLevel: 10},
+		{Name: "Sniper Rifle", Path: "/Game/Pickups/SDU/SDU_SniperRifle.SDU_SniperRifle", MaxLevel: 10},
+		{Name: "Heavy", Path: "/Game/Pickups/SDU/SDU_Heavy.SDU_Heavy", MaxLevel: 10},
+		{Name: "Grenade", Path: "/Game/Pickups/SDU/SDU_Grenade.SDU_Grenade", MaxLevel: 10},
+	}, Vehicles: []assets.Vehicle{
+		{Name: "Test Car", Chassis: "/Test/Vehicles/Chassis_Car.Chassis_Car", Parts: []assets.VehiclePart{
+			{Name: "Test Car Body", Path: "/Test/Vehicles/Part_Car_Body.Part_Car_Body", Kind: assets.VehicleBody},
+			{Name: "Test Car Wheel", Path: "/Test/Vehicles/Part_Car_Wheel.Part_Car_Wheel", Kind: assets.VehicleWheel},
+			{Name: "Test Car Hover Wheel", Path: "/Test/Vehicles/Part_Car_Hover.Part_Car_Hover", Kind: assets.VehicleWheel},
+		}},
+	}, TravelStations: []assets.TravelStation{
+		{Name: "Test Station", Level: assets.Levels[0].Path, Path: Station},
+		{Name: "Test Second Playthrough Station", Level: assets.Levels[0].Path, Path: SecondPlaythroughStation, Playthroughs: []int{1}},
+	}, Cosmetics: []assets.Cosmetic{
+		{Name: "Test Siren Emote", Path: "/Test/Customizations/Emote_Siren.Emote_Siren", Kind: assets.CosmeticEmote, Class: "Siren", Hash: EmoteHash},
+		{Name: "Test Weapon Trinket", Path: "/Test/Customizations/Trinket.Trinket", Kind: assets.CosmeticWeaponTrinket, Hash: TrinketHash},
+	}, Challenges: []assets.Challenge{
+		{Name: "Test Artifact Slot", Path: "/Test/Challenges/Artifact.Artifact_C", Slot: "artifact"},
+		{Name: "Test Class Mod Slot", Path: "/Test/Challenges/ClassMod_Siren.ClassMod_Siren_C", Class: "Siren", Slot: "classmod"},
+		{Name: "Test Class Mod Slot", Path: "/Test/Challenges/ClassMod_Operative.ClassMod_Operative_C", Class: "Operative", Slot: "classmod"},
+	}, EchoLogs: []assets.EchoLog{
+		{Name: "Test ECHO Log", Path: EchoLog},
+	}, CrewQuartersRooms: []assets.CrewQuartersItem{
+		{Name: "Test Room", Path: Room},
+	}, CrewQuartersDecorations: []assets.CrewQuartersItem{
+		{Name: "Test Decoration", Path: Decoration},
+	}, VaultCards: []assets.VaultCard{
+		{ID: VaultCard, Name: "Test Vault Card", MaxLevel: 100, ExperiencePerLevel: 20000},
+	}})
+	return func() {
+		assets.SDUs, assets.Vehicles, assets.TravelStations, assets.Cosmetics = sdus, vehicles, stations, cosmetics
+		assets.Challenges, assets.EchoLogs = challenges, logs
+		assets.CrewQuartersRooms, assets.CrewQuartersDecorations = rooms, decorations
+		assets.VaultCards = cards
+	}
+}
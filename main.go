@@ -1,9 +1,14 @@
+//go:build !js
 // +build !js
 
 package main
 
-import "log"
+import (
+	"os"
+
+	"github.com/cfi2017/bl3-save-core/internal/cli"
+)
 
 func main() {
-	log.Println("stub")
+	os.Exit(cli.Run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
 }
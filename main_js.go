@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/cfi2017/bl3-save-core/pkg/assets"
 	"github.com/cfi2017/bl3-save-core/pkg/character"
+	"github.com/cfi2017/bl3-save-core/pkg/document"
 	"github.com/cfi2017/bl3-save-core/pkg/item"
 	"github.com/cfi2017/bl3-save-core/pkg/pb"
 	"github.com/cfi2017/bl3-save-core/pkg/profile"
@@ -44,6 +46,14 @@ func quit(_ js.Value, _ []js.Value) interface{} {
 	return nil
 }
 
+// formatProto selects the protojson documents described by the published schema instead of the editor's documents.
+// It's passed as optional last argument to the decode and encode functions.
+const formatProto = "proto"
+
+func isProtoFormat(args []js.Value, index int) bool {
+	return len(args) > index && args[index].String() == formatProto
+}
+
 type InMemoryAssetLoader struct {
 	DB   assets.PartsDatabase
 	Btik map[string]string
@@ -165,9 +175,19 @@ func decodeCharacter(_ js.Value, args []js.Value) interface{} {
 	bs := make([]byte, args[0].Length())
 	js.CopyBytesToGo(bs, args[0])
 	r := bytes.NewReader(bs)
-	s, c := character.Deserialize(r, args[1].String())
+	s, c, err := character.Deserialize(r, args[1].String())
+	if err != nil {
+		panic(err)
+	}
+	if isProtoFormat(args, 2) {
+		bs, err := document.MarshalCharacter(s, &c)
+		if err != nil {
+			panic(err)
+		}
+		return string(bs)
+	}
 	// workaround for invalid json parsing values
-	for _, d := range c.GbxZoneMapFodSaveGameData.LevelData {
+	for _, d := range c.GbxZoneMapFodSaveGameData.GetLevelData() {
 		if d.DiscoveryPercentage > math.MaxFloat32 {
 			d.DiscoveryPercentage = -1
 		}
@@ -175,15 +195,16 @@ func decodeCharacter(_ js.Value, args []js.Value) interface{} {
 
 	items := pbArrayToItems(c.InventoryItems)
 
-	bs, err := json.Marshal(struct {
-		Save      shared2.SavFile `json:"save"`
-		Character pb.Character    `json:"character"`
-		Items     ItemRequest     `json:"items"`
+	bs, err = json.Marshal(struct {
+		Save      shared2.SavFile         `json:"save"`
+		Character pb.Character            `json:"character"`
+		Items     ItemRequest             `json:"items"`
+		Unknown   []document.UnknownField `json:"unknown,omitempty"`
 	}{s, c, ItemRequest{
 		Items:    items,
 		Equipped: c.EquippedInventoryList,
 		Active:   c.ActiveWeaponList,
-	}})
+	}, document.FindUnknownFields(&c)})
 	if err != nil {
 		panic(err)
 	}
@@ -194,15 +215,30 @@ func decodeProfile(_ js.Value, args []js.Value) interface{} {
 	bs := make([]byte, args[0].Length())
 	js.CopyBytesToGo(bs, args[0])
 	r := bytes.NewReader(bs)
-	s, p := profile.Deserialize(r, args[1].String())
+	s, p, err := profile.Deserialize(r, args[1].String())
+	if err != nil {
+		panic(err)
+	}
+	if isProtoFormat(args, 2) {
+		bs, err := document.MarshalProfile(s, &p)
+		if err != nil {
+			panic(err)
+		}
+		return string(bs)
+	}
 
-	items := bankToItems(p.BankInventoryList)
+	bank, err := profile.GetBank(&p)
+	if err != nil {
+		panic(err)
+	}
 
-	bs, err := json.Marshal(struct {
-		Save    shared2.SavFile `json:"save"`
-		Profile pb.Profile      `json:"profile"`
-		Items   []item.Item     `json:"items"`
-	}{s, p, items})
+	bs, err = json.Marshal(struct {
+		Save     shared2.SavFile         `json:"save"`
+		Profile  pb.Profile              `json:"profile"`
+		Items    []item.Item             `json:"items"`
+		LostLoot []item.Item             `json:"lostLoot"`
+		Unknown  []document.UnknownField `json:"unknown,omitempty"`
+	}{s, p, bank.Items, bank.LostLoot, document.FindUnknownFields(&p)})
 	if err != nil {
 		panic(err)
 	}
@@ -210,31 +246,21 @@ func decodeProfile(_ js.Value, args []js.Value) interface{} {
 
 }
 
-func bankToItems(list [][]byte) []item.Item {
-	items := make([]item.Item, 0)
-	for _, data := range list {
-		d := make([]byte, len(data))
-		copy(d, data)
-		i, err := item.Deserialize(d)
-		if err != nil {
-			log.Println(err)
-			log.Println(base64.StdEncoding.EncodeToString(data))
-			// c.AbortWithStatus(500)
-			// return
-		}
-		i.Wrapper = &pb.OakInventoryItemSaveGameData{
-			ItemSerialNumber: data,
+func encodeCharacter(_ js.Value, args []js.Value) interface{} {
+	if isProtoFormat(args, 3) {
+		s, c, err := document.UnmarshalCharacter([]byte(args[0].String()))
+		if err := checkUnknownFields(err); err != nil {
+			return nil
 		}
-		items = append(items, i)
+		buf := new(bytes.Buffer)
+		character.Serialize(buf, s, *c, args[2].String())
+		return copySave(args[1], buf.Bytes())
 	}
-	return items
-}
-
-func encodeCharacter(_ js.Value, args []js.Value) interface{} {
 	var data struct {
-		Save      shared2.SavFile `json:"save"`
-		Character pb.Character    `json:"character"`
-		Items     ItemRequest     `json:"items"`
+		Save      shared2.SavFile         `json:"save"`
+		Character pb.Character            `json:"character"`
+		Items     ItemRequest             `json:"items"`
+		Unknown   []document.UnknownField `json:"unknown"`
 	}
 	err := json.Unmarshal([]byte(args[0].String()), &data)
 	if err != nil {
@@ -248,6 +274,10 @@ func encodeCharacter(_ js.Value, args []js.Value) interface{} {
 
 	data.Character.EquippedInventoryList = data.Items.Equipped
 	data.Character.ActiveWeaponList = data.Items.Active
+	if err := document.RestoreUnknownFields(&data.Character, data.Unknown); err != nil {
+		// only fields of removed entries can't be restored, they are dropped with them
+		log.Println(err)
+	}
 
 	buf := new(bytes.Buffer)
 	character.Serialize(buf, data.Save, data.Character, args[2].String())
@@ -258,10 +288,21 @@ func encodeCharacter(_ js.Value, args []js.Value) interface{} {
 }
 
 func encodeProfile(_ js.Value, args []js.Value) interface{} {
+	if isProtoFormat(args, 3) {
+		s, p, err := document.UnmarshalProfile([]byte(args[0].String()))
+		if err := checkUnknownFields(err); err != nil {
+			return nil
+		}
+		buf := new(bytes.Buffer)
+		profile.Serialize(buf, s, *p, args[2].String())
+		return copySave(args[1], buf.Bytes())
+	}
 	var data struct {
-		Save    shared2.SavFile `json:"save"`
-		Profile pb.Profile      `json:"profile"`
-		Items   []item.Item     `json:"items"`
+		Save     shared2.SavFile         `json:"save"`
+		Profile  pb.Profile              `json:"profile"`
+		Items    []item.Item             `json:"items"`
+		LostLoot []item.Item             `json:"lostLoot"`
+		Unknown  []document.UnknownField `json:"unknown"`
 	}
 	err := json.Unmarshal([]byte(args[0].String()), &data)
 	if err != nil {
@@ -269,10 +310,20 @@ func encodeProfile(_ js.Value, args []js.Value) interface{} {
 	}
 	buf := new(bytes.Buffer)
 
-	pba, err := itemsToPBArray(data.Items)
-	data.Profile.BankInventoryList = make([][]byte, len(pba))
-	for i := range pba {
-		data.Profile.BankInventoryList[i] = pba[i].ItemSerialNumber
+	bank := profile.Bank{Items: data.Items, LostLoot: data.LostLoot}
+	if data.LostLoot == nil {
+		// older clients don't send lost loot, keep the profile's lost loot as is
+		bank.LostLoot = make([]item.Item, len(data.Profile.LostLootInventoryList))
+		for i, serial := range data.Profile.LostLootInventoryList {
+			bank.LostLoot[i] = item.Item{Wrapper: &pb.OakInventoryItemSaveGameData{ItemSerialNumber: serial}}
+		}
+	}
+	if err := bank.Apply(&data.Profile); err != nil {
+		panic(err)
+	}
+	if err := document.RestoreUnknownFields(&data.Profile, data.Unknown); err != nil {
+		// only fields of removed entries can't be restored, they are dropped with them
+		log.Println(err)
 	}
 	profile.Serialize(buf, data.Save, data.Profile, args[2].String())
 	bs, _ := ioutil.ReadAll(buf)
@@ -281,6 +332,26 @@ func encodeProfile(_ js.Value, args []js.Value) interface{} {
 	return dst
 }
 
+// checkUnknownFields logs unknown fields that couldn't be restored, they were dropped with their removed entries.
+func checkUnknownFields(err error) error {
+	var missing *document.MissingFieldsError
+	if errors.As(err, &missing) {
+		log.Println(err)
+		return nil
+	}
+	if err != nil {
+		log.Println(err)
+	}
+	return err
+}
+
+// copySave copies an encoded save into a buffer created by the given allocation function.
+func copySave(alloc js.Value, bs []byte) js.Value {
+	dst := alloc.Invoke(len(bs))
+	js.CopyBytesToJS(dst, bs)
+	return dst
+}
+
 func pbArrayToItems(inventoryItems []*pb.OakInventoryItemSaveGameData) []item.Item {
 	items := make([]item.Item, 0)
 	for _, data := range inventoryItems {
@@ -3,6 +3,7 @@ package assets
 import (
 	"encoding/json"
 	"io/ioutil"
+	"path/filepath"
 	"sync"
 )
 
@@ -39,11 +40,11 @@ func (s *StaticFileAssetLoader) GetDB() PartsDatabase {
 func (s *StaticFileAssetLoader) Load() error {
 	var err error
 	s.once.Do(func() {
-		s.btik, err = LoadPartMap("balance_to_inv_key.json")
+		s.btik, err = LoadPartMap(filepath.Join(s.Pwd, "balance_to_inv_key.json"))
 		if err != nil {
 			return
 		}
-		s.db, err = LoadPartsDatabase("inventory_raw.json")
+		s.db, err = LoadPartsDatabase(filepath.Join(s.Pwd, "inventory_raw.json"))
 	})
 	return err
 }
@@ -0,0 +1,101 @@
+package assets
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+)
+
+// Catalog is game data that can neither be read from saves nor from the item database, e.g. SDU capacities.
+// None of it is built in, it's read from catalog.json in the assets directory, see LoadCatalog.
+type Catalog struct {
+	SDUs                    []SDU              `json:"sdus"`
+	Vehicles                []Vehicle          `json:"vehicles"`
+	TravelStations          []TravelStation    `json:"travelStations"`
+	Cosmetics               []Cosmetic         `json:"cosmetics"`
+	Challenges              []Challenge        `json:"challenges"`
+	EchoLogs                []EchoLog          `json:"echoLogs"`
+	CrewQuartersRooms       []CrewQuartersItem `json:"crewQuartersRooms"`
+	CrewQuartersDecorations []CrewQuartersItem `json:"crewQuartersDecorations"`
+	VaultCards              []VaultCard        `json:"vaultCards"`
+}
+
+/*
+LoadCatalog reads a catalog from the given JSON file and registers it, see RegisterCatalog.
+*/
+func LoadCatalog(file string) error {
+	bs, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	var c Catalog
+	if err := json.Unmarshal(bs, &c); err != nil {
+		return err
+	}
+	RegisterCatalog(c)
+	return nil
+}
+
+/*
+RegisterCatalog adds the entries of a catalog to the package's lists.
+Entries replace the entry with the same path, for vehicles the same chassis and for vault cards the same id,
+others are appended.
+*/
+func RegisterCatalog(c Catalog) {
+	for _, s := range c.SDUs {
+		s := s
+		register(len(SDUs), func(i int) bool { return strings.EqualFold(SDUs[i].Path, s.Path) },
+			func(i int) { SDUs[i] = s }, func() { SDUs = append(SDUs, s) })
+	}
+	for _, v := range c.Vehicles {
+		v := v
+		register(len(Vehicles), func(i int) bool { return strings.EqualFold(Vehicles[i].Chassis, v.Chassis) },
+			func(i int) { Vehicles[i] = v }, func() { Vehicles = append(Vehicles, v) })
+	}
+	for _, s := range c.TravelStations {
+		s := s
+		register(len(TravelStations), func(i int) bool { return strings.EqualFold(TravelStations[i].Path, s.Path) },
+			func(i int) { TravelStations[i] = s }, func() { TravelStations = append(TravelStations, s) })
+	}
+	for _, cosmetic := range c.Cosmetics {
+		cosmetic := cosmetic
+		register(len(Cosmetics), func(i int) bool { return strings.EqualFold(Cosmetics[i].Path, cosmetic.Path) },
+			func(i int) { Cosmetics[i] = cosmetic }, func() { Cosmetics = append(Cosmetics, cosmetic) })
+	}
+	for _, challenge := range c.Challenges {
+		challenge := challenge
+		register(len(Challenges), func(i int) bool { return strings.EqualFold(Challenges[i].Path, challenge.Path) },
+			func(i int) { Challenges[i] = challenge }, func() { Challenges = append(Challenges, challenge) })
+	}
+	for _, l := range c.EchoLogs {
+		l := l
+		register(len(EchoLogs), func(i int) bool { return strings.EqualFold(EchoLogs[i].Path, l.Path) },
+			func(i int) { EchoLogs[i] = l }, func() { EchoLogs = append(EchoLogs, l) })
+	}
+	for _, r := range c.CrewQuartersRooms {
+		r := r
+		register(len(CrewQuartersRooms), func(i int) bool { return strings.EqualFold(CrewQuartersRooms[i].Path, r.Path) },
+			func(i int) { CrewQuartersRooms[i] = r }, func() { CrewQuartersRooms = append(CrewQuartersRooms, r) })
+	}
+	for _, d := range c.CrewQuartersDecorations {
+		d := d
+		register(len(CrewQuartersDecorations), func(i int) bool { return strings.EqualFold(CrewQuartersDecorations[i].Path, d.Path) },
+			func(i int) { CrewQuartersDecorations[i] = d }, func() { CrewQuartersDecorations = append(CrewQuartersDecorations, d) })
+	}
+	for _, v := range c.VaultCards {
+		v := v
+		register(len(VaultCards), func(i int) bool { return VaultCards[i].ID == v.ID },
+			func(i int) { VaultCards[i] = v }, func() { VaultCards = append(VaultCards, v) })
+	}
+}
+
+// register calls replace with the index of the first of n entries that matches, or add if none does.
+func register(n int, matches func(i int) bool, replace func(i int), add func()) {
+	for i := 0; i < n; i++ {
+		if matches(i) {
+			replace(i)
+			return
+		}
+	}
+	add()
+}
@@ -0,0 +1,53 @@
+package assets
+
+import (
+	"strings"
+)
+
+// Challenge is a challenge whose completion unlocks a feature for the character.
+// Class is empty for challenges that apply to every class.
+// Slot is the name of the equipment slot the challenge unlocks (see ParseInventorySlot), if any.
+type Challenge struct {
+	Name  string `json:"name"`
+	Path  string `json:"path"`
+	Class string `json:"class"`
+	Slot  string `json:"slot"`
+}
+
+// Challenges contains the challenges known by name. None are built in, they're registered from the catalog.
+var Challenges = []Challenge{}
+
+/*
+FindChallenge returns the challenge matching the given friendly name or path for the given class.
+Class specific challenges only match by name if class is the challenge's class name.
+Names are matched case-insensitively.
+*/
+func FindChallenge(name, class string) (Challenge, bool) {
+	for _, c := range Challenges {
+		if strings.EqualFold(c.Path, name) {
+			return c, true
+		}
+		if strings.EqualFold(c.Name, name) && (c.Class == "" || strings.EqualFold(c.Class, class)) {
+			return c, true
+		}
+	}
+	return Challenge{}, false
+}
+
+/*
+GetSlotChallenges returns the registered challenges that unlock an equipment slot for the given class.
+*/
+func GetSlotChallenges(class string) []Challenge {
+	challenges := make([]Challenge, 0)
+	for _, c := range Challenges {
+		if c.Slot != "" && (c.Class == "" || strings.EqualFold(c.Class, class)) {
+			challenges = append(challenges, c)
+		}
+	}
+	return challenges
+}
+
+// IsAssetPath reports whether name looks like a game asset path rather than a friendly name.
+func IsAssetPath(name string) bool {
+	return strings.HasPrefix(name, "/Game/")
+}
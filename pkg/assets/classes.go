@@ -0,0 +1,31 @@
+package assets
+
+import "strings"
+
+// Class is a playable character class.
+type Class struct {
+	Name      string `json:"name"`
+	Character string `json:"character"`
+	Path      string `json:"path"`
+}
+
+// Classes contains all playable classes.
+var Classes = []Class{
+	{Name: "Beastmaster", Character: "FL4K", Path: "/Game/PlayerCharacters/Beastmaster/PlayerClassId_Beastmaster.PlayerClassId_Beastmaster"},
+	{Name: "Gunner", Character: "Moze", Path: "/Game/PlayerCharacters/Gunner/PlayerClassId_Gunner.PlayerClassId_Gunner"},
+	{Name: "Operative", Character: "Zane", Path: "/Game/PlayerCharacters/Operative/PlayerClassId_Operative.PlayerClassId_Operative"},
+	{Name: "Siren", Character: "Amara", Path: "/Game/PlayerCharacters/SirenBrawler/PlayerClassId_Siren.PlayerClassId_Siren"},
+}
+
+/*
+FindClass returns the class matching the given class name, character name or class path.
+Names are matched case-insensitively.
+*/
+func FindClass(name string) (Class, bool) {
+	for _, c := range Classes {
+		if strings.EqualFold(c.Name, name) || strings.EqualFold(c.Character, name) || strings.EqualFold(c.Path, name) {
+			return c, true
+		}
+	}
+	return Class{}, false
+}
@@ -0,0 +1,134 @@
+package assets
+
+import (
+	"strings"
+)
+
+// CosmeticKind is the category of a cosmetic.
+type CosmeticKind string
+
+const (
+	CosmeticHead          CosmeticKind = "head"
+	CosmeticSkin          CosmeticKind = "skin"
+	CosmeticEmote         CosmeticKind = "emote"
+	CosmeticEchoTheme     CosmeticKind = "echo_theme"
+	CosmeticWeaponTrinket CosmeticKind = "weapon_trinket"
+	CosmeticWeaponSkin    CosmeticKind = "weapon_skin"
+)
+
+const (
+	customizationsBasePath = "/Game/PlayerCharacters/_Customizations/"
+	echoDeviceFolder       = "EchoDevice"
+)
+
+// Cosmetic is an unlockable customization.
+// Class is empty for cosmetics that can be used by every class.
+// Hash identifies emotes equipped on a character and weapon trinkets and skins unlocked on a profile,
+// it's zero if it isn't known.
+type Cosmetic struct {
+	Name    string       `json:"name"`
+	Path    string       `json:"path"`
+	Kind    CosmeticKind `json:"kind"`
+	Class   string       `json:"class"`
+	Hash    uint32       `json:"hash"`
+	Default bool         `json:"default"`
+}
+
+// Cosmetics contains the default head and skin of every class and the default ECHO theme.
+// Other heads, skins and ECHO themes are recognized by their path, see FindCosmetic.
+// Emotes, weapon trinkets and weapon skins are registered from the catalog.
+var Cosmetics = buildCosmetics()
+
+// IsInventoryPart reports whether the cosmetic is stored in the profile as an inventory customization part.
+func (c Cosmetic) IsInventoryPart() bool {
+	return c.Kind == CosmeticWeaponTrinket || c.Kind == CosmeticWeaponSkin
+}
+
+func buildCosmetics() []Cosmetic {
+	cosmetics := make([]Cosmetic, 0)
+	kinds := []struct {
+		kind   CosmeticKind
+		folder string
+		prefix string
+	}{
+		{CosmeticHead, "Heads", "CustomHead"},
+		{CosmeticSkin, "Skins", "CustomSkin"},
+	}
+	for _, class := range Classes {
+		for _, k := range kinds {
+			id := k.prefix + "_" + class.Name + "_Default"
+			cosmetics = append(cosmetics, Cosmetic{
+				Name:    class.Character + " default " + string(k.kind),
+				Path:    customizationsBasePath + classFolder(class) + "/" + k.folder + "/" + id + "." + id,
+				Kind:    k.kind,
+				Class:   class.Name,
+				Default: true,
+			})
+		}
+	}
+	return append(cosmetics, Cosmetic{
+		Name:    "Default ECHO theme",
+		Path:    customizationsBasePath + echoDeviceFolder + "/ECHOTheme_Default.ECHOTheme_Default",
+		Kind:    CosmeticEchoTheme,
+		Default: true,
+	})
+}
+
+// classFolder returns the folder holding the assets of a class, e.g. SirenBrawler for the Siren.
+func classFolder(class Class) string {
+	path := strings.TrimPrefix(class.Path, "/Game/PlayerCharacters/")
+	return path[:strings.Index(path, "/")]
+}
+
+/*
+FindCosmetic returns the cosmetic matching the given friendly name or path.
+Names are matched case-insensitively. Paths of heads, skins and ECHO themes that aren't part of the catalog
+are recognized by their folder and returned with their object name as name.
+*/
+func FindCosmetic(name string) (Cosmetic, bool) {
+	for _, c := range Cosmetics {
+		if strings.EqualFold(c.Name, name) || strings.EqualFold(c.Path, name) {
+			return c, true
+		}
+	}
+	return parseCosmeticPath(name)
+}
+
+// FindCosmeticByHash returns the cosmetic with the given hash.
+func FindCosmeticByHash(hash uint32) (Cosmetic, bool) {
+	for _, c := range Cosmetics {
+		if c.Hash != 0 && c.Hash == hash {
+			return c, true
+		}
+	}
+	return Cosmetic{}, false
+}
+
+// parseCosmeticPath determines kind and class of a customization from its path.
+func parseCosmeticPath(path string) (Cosmetic, bool) {
+	if !strings.HasPrefix(path, customizationsBasePath) {
+		return Cosmetic{}, false
+	}
+	segments := strings.Split(strings.TrimPrefix(path, customizationsBasePath), "/")
+	c := Cosmetic{Name: path[strings.LastIndex(path, ".")+1:], Path: path}
+	switch {
+	case len(segments) == 2 && segments[0] == echoDeviceFolder:
+		c.Kind = CosmeticEchoTheme
+		return c, true
+	case len(segments) != 3:
+		return Cosmetic{}, false
+	case segments[1] == "Heads":
+		c.Kind = CosmeticHead
+	case segments[1] == "Skins":
+		c.Kind = CosmeticSkin
+	default:
+		return Cosmetic{}, false
+	}
+	for _, class := range Classes {
+		if classFolder(class) == segments[0] {
+			c.Class = class.Name
+			return c, true
+		}
+	}
+	return Cosmetic{}, false
+}
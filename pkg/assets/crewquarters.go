@@ -0,0 +1,46 @@
+package assets
+
+import (
+	"strings"
+)
+
+// CrewQuartersItem is a room or decoration that can be placed in the crew quarters.
+type CrewQuartersItem struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// CrewQuartersRooms contains the crew quarters rooms known by name. None are built in, they're registered from the catalog.
+var CrewQuartersRooms = []CrewQuartersItem{}
+
+// CrewQuartersDecorations contains the crew quarters decorations known by name. None are built in,
+// they're registered from the catalog.
+var CrewQuartersDecorations = []CrewQuartersItem{}
+
+/*
+FindCrewQuartersRoom returns the room matching the given friendly name or path.
+Names are matched case-insensitively, asset paths that aren't registered are returned with their object name as name.
+*/
+func FindCrewQuartersRoom(name string) (CrewQuartersItem, bool) {
+	return findCrewQuartersItem(CrewQuartersRooms, name)
+}
+
+/*
+FindCrewQuartersDecoration returns the decoration matching the given friendly name or path.
+Names are matched case-insensitively, asset paths that aren't registered are returned with their object name as name.
+*/
+func FindCrewQuartersDecoration(name string) (CrewQuartersItem, bool) {
+	return findCrewQuartersItem(CrewQuartersDecorations, name)
+}
+
+func findCrewQuartersItem(items []CrewQuartersItem, name string) (CrewQuartersItem, bool) {
+	for _, i := range items {
+		if strings.EqualFold(i.Name, name) || strings.EqualFold(i.Path, name) {
+			return i, true
+		}
+	}
+	if IsAssetPath(name) {
+		return CrewQuartersItem{Name: name[strings.LastIndex(name, ".")+1:], Path: name}, true
+	}
+	return CrewQuartersItem{}, false
+}
@@ -0,0 +1,30 @@
+package assets
+
+import (
+	"strings"
+)
+
+// EchoLog is an ECHO log that can be collected.
+type EchoLog struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// EchoLogs contains the ECHO logs known by name. None are built in, they're registered from the catalog.
+var EchoLogs = []EchoLog{}
+
+/*
+FindEchoLog returns the ECHO log matching the given friendly name or path.
+Names are matched case-insensitively, asset paths that aren't registered are returned with their object name as name.
+*/
+func FindEchoLog(name string) (EchoLog, bool) {
+	for _, l := range EchoLogs {
+		if strings.EqualFold(l.Name, name) || strings.EqualFold(l.Path, name) {
+			return l, true
+		}
+	}
+	if IsAssetPath(name) {
+		return EchoLog{Name: name[strings.LastIndex(name, ".")+1:], Path: name}, true
+	}
+	return EchoLog{}, false
+}
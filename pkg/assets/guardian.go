@@ -0,0 +1,58 @@
+package assets
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GuardianReward is a guardian rank reward that tokens can be spent on.
+type GuardianReward struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// GuardianRewards contains all guardian rank rewards.
+var GuardianRewards = buildGuardianRewards()
+
+func buildGuardianRewards() []GuardianReward {
+	rewards := []struct{ name, id string }{
+		{"Accuracy", "Accuracy"},
+		{"Action Skill Cooldown", "ActionSkillCooldown"},
+		{"Critical Damage", "CriticalDamage"},
+		{"FFYL Duration", "FFYLDuration"},
+		{"FFYL Movement Speed", "FFYLMovementSpeed"},
+		{"Grenade Damage", "GrenadeDamage"},
+		{"Gun Damage", "GunDamage"},
+		{"Gun Fire Rate", "GunFireRate"},
+		{"Max Health", "MaxHealth"},
+		{"Melee Damage", "MeleeDamage"},
+		{"Rarity Rate", "RarityRate"},
+		{"Recoil Reduction", "RecoilReduction"},
+		{"Reload Speed", "ReloadSpeed"},
+		{"Shield Capacity", "ShieldCapacity"},
+		{"Shield Recharge Delay", "ShieldRechargeDelay"},
+		{"Shield Recharge Rate", "ShieldRechargeRate"},
+		{"Vehicle Damage", "VehicleDamage"},
+	}
+	result := make([]GuardianReward, len(rewards))
+	for i, r := range rewards {
+		result[i] = GuardianReward{
+			Name: r.name,
+			Path: fmt.Sprintf("/Game/PlayerCharacters/_Shared/_Design/GuardianRank/GuardianReward_%s.GuardianReward_%s", r.id, r.id),
+		}
+	}
+	return result
+}
+
+/*
+FindGuardianReward returns the guardian reward matching the given friendly name or path.
+Names are matched case-insensitively.
+*/
+func FindGuardianReward(name string) (GuardianReward, bool) {
+	for _, r := range GuardianRewards {
+		if strings.EqualFold(r.Name, name) || strings.EqualFold(r.Path, name) {
+			return r, true
+		}
+	}
+	return GuardianReward{}, false
+}
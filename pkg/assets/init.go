@@ -1,7 +1,13 @@
+//go:build !js
 // +build !js
 
 package assets
 
+import (
+	"os"
+	"sync"
+)
+
 func init() {
 	p, err := os.Getwd()
 	if err != nil {
@@ -0,0 +1,106 @@
+package assets
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+// SDU is a storage deck upgrade. Capacity related SDUs grant PerLevel additional slots per level on top of Base.
+// Profile SDUs are stored in the profile and shared by all characters, all others are stored per character.
+// MaxLevel, Base and PerLevel are zero if they aren't known, see Catalog.
+type SDU struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	MaxLevel int    `json:"maxLevel"`
+	Base     int    `json:"base"`
+	PerLevel int    `json:"perLevel"`
+	Profile  bool   `json:"profile"`
+}
+
+const (
+	SDUBackpack = "Backpack"
+	SDUBank     = "Bank"
+	SDULostLoot = "Lost Loot"
+)
+
+// SDUs contains all storage deck upgrades. Level caps and capacities differ between game versions
+// and aren't built in, they're registered from the catalog.
+var SDUs = []SDU{
+	{Name: SDUBackpack, Path: "/Game/Pickups/SDU/SDU_Backpack.SDU_Backpack"},
+	{Name: SDUBank, Path: "/Game/Pickups/SDU/SDU_Bank.SDU_Bank", Profile: true},
+	{Name: SDULostLoot, Path: "/Game/Pickups/SDU/SDU_LostLoot.SDU_LostLoot", Profile: true},
+	{Name: "Assault Rifle", Path: "/Game/Pickups/SDU/SDU_AssaultRifle.SDU_AssaultRifle"},
+	{Name: "Pistol", Path: "/Game/Pickups/SDU/SDU_Pistol.SDU_Pistol"},
+	{Name: "SMG", Path: "/Game/Pickups/SDU/SDU_SMG.SDU_SMG"},
+	{Name: "Shotgun", Path: "/Game/Pickups/SDU/SDU_Shotgun.SDU_Shotgun"},
+	{Name: "Sniper Rifle", Path: "/Game/Pickups/SDU/SDU_SniperRifle.SDU_SniperRifle"},
+	{Name: "Heavy", Path: "/Game/Pickups/SDU/SDU_Heavy.SDU_Heavy"},
+	{Name: "Grenade", Path: "/Game/Pickups/SDU/SDU_Grenade.SDU_Grenade"},
+}
+
+/*
+FindSDU returns the SDU matching the given friendly name or path.
+Names are matched case-insensitively.
+*/
+func FindSDU(name string) (SDU, bool) {
+	for _, s := range SDUs {
+		if strings.EqualFold(s.Name, name) || strings.EqualFold(s.Path, name) {
+			return s, true
+		}
+	}
+	return SDU{}, false
+}
+
+/*
+CheckSDUMaxLevels returns an error if the highest level of a profile or character SDU isn't known.
+*/
+func CheckSDUMaxLevels(profile bool) error {
+	for _, s := range SDUs {
+		if s.Profile == profile && s.MaxLevel == 0 {
+			return fmt.Errorf("highest level of SDU %s not known, it's read from the catalog", s.Name)
+		}
+	}
+	return nil
+}
+
+/*
+Capacity returns the number of slots the SDU grants, given the SDU levels of a character or profile.
+Returns -1 if the capacity isn't known.
+*/
+func (s SDU) Capacity(list []*pb.OakSDUSaveGameData) int {
+	if s.Base == 0 {
+		return -1
+	}
+	level := 0
+	for _, sdu := range list {
+		if sdu.SduDataPath == s.Path {
+			level = int(sdu.SduLevel)
+		}
+	}
+	if s.MaxLevel > 0 && level > s.MaxLevel {
+		level = s.MaxLevel
+	}
+	return s.Base + level*s.PerLevel
+}
+
+/*
+SetLevel sets the level of the SDU in the given list and returns the updated list.
+Returns an error if the level is out of range. Only negative levels are rejected if the maximum level isn't known.
+*/
+func (s SDU) SetLevel(list []*pb.OakSDUSaveGameData, level int) ([]*pb.OakSDUSaveGameData, error) {
+	if level < 0 {
+		return list, fmt.Errorf("invalid level %d for SDU %s", level, s.Name)
+	}
+	if s.MaxLevel > 0 && level > s.MaxLevel {
+		return list, fmt.Errorf("invalid level %d for SDU %s, expected 0 to %d", level, s.Name, s.MaxLevel)
+	}
+	for _, sdu := range list {
+		if sdu.SduDataPath == s.Path {
+			sdu.SduLevel = int32(level)
+			return list, nil
+		}
+	}
+	return append(list, &pb.OakSDUSaveGameData{SduDataPath: s.Path, SduLevel: int32(level)}), nil
+}
@@ -0,0 +1,40 @@
+package assets
+
+import "strings"
+
+// InventorySlot is an equipment slot of a character.
+type InventorySlot struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Weapon bool   `json:"weapon"`
+}
+
+// slotPrefix is the object name prefix of slot assets, e.g. BPInvSlot_Weapon1.
+const slotPrefix = "bpinvslot_"
+
+/*
+ParseInventorySlot derives the slot from a SlotDataPath as found in a character's EquippedInventoryList.
+The name is the lowercased object name without its BPInvSlot_ prefix, e.g. weapon1 for
+/Game/Gear/Weapons/_Shared/_Design/InventorySlots/BPInvSlot_Weapon1.BPInvSlot_Weapon1.
+Paths not following that pattern are named by their full path.
+*/
+func ParseInventorySlot(path string) InventorySlot {
+	name := path
+	if i := strings.LastIndexAny(name, "./"); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.ToLower(name)
+	if !strings.HasPrefix(name, slotPrefix) || name == slotPrefix {
+		return InventorySlot{Name: path, Path: path}
+	}
+	name = strings.TrimPrefix(name, slotPrefix)
+	return InventorySlot{Name: name, Path: path, Weapon: strings.HasPrefix(name, "weapon")}
+}
+
+/*
+Match reports whether the slot matches the given name (e.g. weapon1, shield) or path.
+Names are matched case-insensitively.
+*/
+func (s InventorySlot) Match(name string) bool {
+	return strings.EqualFold(s.Name, name) || strings.EqualFold(s.Path, name)
+}
@@ -0,0 +1,143 @@
+package assets
+
+import "strings"
+
+// Planet is a planet that can be selected on the galaxy map.
+type Planet struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// Level is a map that can be discovered.
+type Level struct {
+	Name   string `json:"name"`
+	Planet string `json:"planet"`
+	Path   string `json:"path"`
+}
+
+// TravelStation is a fast travel station. Level is the path of the level the station is in.
+// Playthroughs are the zero-based playthroughs the station can be activated in, all if empty.
+type TravelStation struct {
+	Name         string `json:"name"`
+	Level        string `json:"level"`
+	Path         string `json:"path"`
+	Playthroughs []int  `json:"playthroughs"`
+}
+
+// InPlaythrough reports whether the station can be activated in the zero-based playthrough pt.
+func (s TravelStation) InPlaythrough(pt int) bool {
+	if len(s.Playthroughs) == 0 {
+		return true
+	}
+	for _, p := range s.Playthroughs {
+		if p == pt {
+			return true
+		}
+	}
+	return false
+}
+
+// Planets contains all known planets. Append to register additional planets.
+var Planets = []Planet{
+	{Name: "Pandora", Path: "/Game/UI/FastTravel/Planets/Planet_Pandora.Planet_Pandora"},
+	{Name: "Sanctuary", Path: "/Game/UI/FastTravel/Planets/Planet_Sanctuary.Planet_Sanctuary"},
+	{Name: "Promethea", Path: "/Game/UI/FastTravel/Planets/Planet_Promethea.Planet_Promethea"},
+	{Name: "Athenas", Path: "/Game/UI/FastTravel/Planets/Planet_Athenas.Planet_Athenas"},
+	{Name: "Eden-6", Path: "/Game/UI/FastTravel/Planets/Planet_Eden6.Planet_Eden6"},
+	{Name: "Nekrotafeyo", Path: "/Game/UI/FastTravel/Planets/Planet_Nekrotafeyo.Planet_Nekrotafeyo"},
+}
+
+// Levels contains the levels of the base game. Append to register additional levels.
+var Levels = []Level{
+	{Name: "The Droughts", Planet: "Pandora", Path: "/Game/Maps/Zone_0/Prologue/Prologue_P"},
+	{Name: "The Holy Broadcast Center", Planet: "Pandora", Path: "/Game/Maps/Zone_0/Sacrifice/Sacrifice_P"},
+	{Name: "Ascension Bluff", Planet: "Pandora", Path: "/Game/Maps/Zone_0/Towers/Towers_P"},
+	{Name: "Devil's Razor", Planet: "Pandora", Path: "/Game/Maps/Zone_0/Desert/Desert_P"},
+	{Name: "Carnivora", Planet: "Pandora", Path: "/Game/Maps/Zone_0/Motorcade/Motorcade_P"},
+	{Name: "Sanctuary", Planet: "Sanctuary", Path: "/Game/Maps/Sanctuary3/Sanctuary3_P"},
+	{Name: "Meridian Outskirts", Planet: "Promethea", Path: "/Game/Maps/Zone_1/Outskirts/Outskirts_P"},
+	{Name: "Meridian Metroplex", Planet: "Promethea", Path: "/Game/Maps/Zone_1/City/City_P"},
+	{Name: "Lectra City", Planet: "Promethea", Path: "/Game/Maps/Zone_1/Cooling/Cooling_P"},
+	{Name: "Skywell-27", Planet: "Promethea", Path: "/Game/Maps/Zone_1/OrbitalPlatform/OrbitalPlatform_P"},
+	{Name: "Atlas HQ", Planet: "Promethea", Path: "/Game/Maps/Zone_1/AtlasHQ/AtlasHQ_P"},
+	{Name: "Athenas", Planet: "Athenas", Path: "/Game/Maps/Zone_2/Monastery/Monastery_P"},
+	{Name: "Jakobs Estate", Planet: "Eden-6", Path: "/Game/Maps/Zone_2/Mansion/Mansion_P"},
+	{Name: "Floodmoor Basin", Planet: "Eden-6", Path: "/Game/Maps/Zone_2/Wetlands/Wetlands_P"},
+	{Name: "The Anvil", Planet: "Eden-6", Path: "/Game/Maps/Zone_2/Prison/Prison_P"},
+	{Name: "Desolation's Edge", Planet: "Nekrotafeyo", Path: "/Game/Maps/Zone_3/Desolate/Desolate_P"},
+	{Name: "Destroyer's Rift", Planet: "Nekrotafeyo", Path: "/Game/Maps/Zone_3/FinalBoss/FinalBoss_P"},
+}
+
+// TravelStations contains the fast travel stations known by level. None are built in, they're registered from the catalog.
+// Stations the character has already activated in any playthrough are read from the save, see character.GetTravelStations.
+var TravelStations = []TravelStation{}
+
+/*
+FindLevel returns the level matching the given friendly name, level path or short map name (e.g. Prologue_P).
+Names are matched case-insensitively.
+*/
+func FindLevel(name string) (Level, bool) {
+	for _, l := range Levels {
+		if strings.EqualFold(l.Name, name) || strings.EqualFold(l.Path, name) ||
+			strings.EqualFold(l.Path[strings.LastIndex(l.Path, "/")+1:], name) {
+			return l, true
+		}
+	}
+	return Level{}, false
+}
+
+/*
+FindPlanet returns the planet matching the given friendly name or path.
+Names are matched case-insensitively.
+*/
+func FindPlanet(name string) (Planet, bool) {
+	for _, p := range Planets {
+		if strings.EqualFold(p.Name, name) || strings.EqualFold(p.Path, name) {
+			return p, true
+		}
+	}
+	return Planet{}, false
+}
+
+/*
+FindTravelStation returns the station matching the given friendly name or path.
+Names are matched case-insensitively, asset paths that aren't registered are returned with their object name as name.
+*/
+func FindTravelStation(name string) (TravelStation, bool) {
+	for _, s := range TravelStations {
+		if strings.EqualFold(s.Name, name) || strings.EqualFold(s.Path, name) {
+			return s, true
+		}
+	}
+	if IsAssetPath(name) {
+		return TravelStation{Name: name[strings.LastIndex(name, ".")+1:], Path: name}, true
+	}
+	return TravelStation{}, false
+}
+
+/*
+GetPlanetLevels returns all levels on the given planet.
+*/
+func GetPlanetLevels(planet string) []Level {
+	levels := make([]Level, 0)
+	for _, l := range Levels {
+		if l.Planet == planet {
+			levels = append(levels, l)
+		}
+	}
+	return levels
+}
+
+/*
+GetLevelTravelStations returns the registered stations of the level with the given path
+that can be activated in the zero-based playthrough pt.
+*/
+func GetLevelTravelStations(level string, pt int) []TravelStation {
+	stations := make([]TravelStation, 0)
+	for _, s := range TravelStations {
+		if s.Level == level && s.InPlaythrough(pt) {
+			stations = append(stations, s)
+		}
+	}
+	return stations
+}
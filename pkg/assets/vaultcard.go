@@ -0,0 +1,42 @@
+package assets
+
+import (
+	"strings"
+)
+
+// VaultCard is a seasonal vault card. Levels are earned every ExperiencePerLevel experience points.
+type VaultCard struct {
+	ID                 uint32 `json:"id"`
+	Name               string `json:"name"`
+	MaxLevel           int    `json:"maxLevel"`
+	ExperiencePerLevel int64  `json:"experiencePerLevel"`
+}
+
+// VaultCards contains the vault cards known by id. None are built in, they're registered from the catalog.
+// Gear rewards aren't part of the catalog, the item data of the rewards isn't known.
+var VaultCards = []VaultCard{}
+
+/*
+FindVaultCard returns the vault card with the given id.
+*/
+func FindVaultCard(id uint32) (VaultCard, bool) {
+	for _, v := range VaultCards {
+		if v.ID == id {
+			return v, true
+		}
+	}
+	return VaultCard{}, false
+}
+
+/*
+FindVaultCardByName returns the vault card matching the given friendly name.
+Names are matched case-insensitively.
+*/
+func FindVaultCardByName(name string) (VaultCard, bool) {
+	for _, v := range VaultCards {
+		if strings.EqualFold(v.Name, name) {
+			return v, true
+		}
+	}
+	return VaultCard{}, false
+}
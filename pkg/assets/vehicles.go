@@ -0,0 +1,63 @@
+package assets
+
+import "strings"
+
+// VehiclePartKind is the loadout slot a vehicle part can be equipped in.
+type VehiclePartKind string
+
+const (
+	VehicleBody          VehiclePartKind = "body"
+	VehicleWheel         VehiclePartKind = "wheel"
+	VehicleArmor         VehiclePartKind = "armor"
+	VehicleCoreMod       VehiclePartKind = "core_mod"
+	VehicleGunnerWeapon  VehiclePartKind = "gunner_weapon"
+	VehicleDriverWeapon  VehiclePartKind = "driver_weapon"
+	VehicleOrnament      VehiclePartKind = "ornament"
+	VehicleMaterialDecal VehiclePartKind = "material_decal"
+	VehicleMaterial      VehiclePartKind = "material"
+)
+
+// VehiclePart is a part or skin that can be unlocked for a vehicle.
+type VehiclePart struct {
+	Name string          `json:"name"`
+	Path string          `json:"path"`
+	Kind VehiclePartKind `json:"kind"`
+}
+
+// Vehicle is a vehicle chassis and the parts that can be equipped on it.
+type Vehicle struct {
+	Name    string        `json:"name"`
+	Chassis string        `json:"chassis"`
+	Parts   []VehiclePart `json:"parts"`
+}
+
+// Vehicles contains the vehicles known by their parts. None are built in, they're registered from the catalog.
+var Vehicles = []Vehicle{}
+
+/*
+FindVehicle returns the vehicle matching the given friendly name or chassis path.
+Names are matched case-insensitively.
+*/
+func FindVehicle(name string) (Vehicle, bool) {
+	for _, v := range Vehicles {
+		if strings.EqualFold(v.Name, name) || strings.EqualFold(v.Chassis, name) {
+			return v, true
+		}
+	}
+	return Vehicle{}, false
+}
+
+/*
+FindVehiclePart returns the vehicle part matching the given friendly name or path.
+Names are matched case-insensitively.
+*/
+func FindVehiclePart(name string) (VehiclePart, bool) {
+	for _, v := range Vehicles {
+		for _, p := range v.Parts {
+			if strings.EqualFold(p.Name, name) || strings.EqualFold(p.Path, name) {
+				return p, true
+			}
+		}
+	}
+	return VehiclePart{}, false
+}
@@ -0,0 +1,130 @@
+package character
+
+import (
+	"fmt"
+
+	"github.com/cfi2017/bl3-save-core/pkg/assets"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+/*
+CompleteChallenges marks the given challenges as completed.
+Challenges are resolved through assets.FindChallenge using the character's class, so the name of a class specific
+challenge completes the one of the character's own class. Challenge class paths that aren't registered are completed as given.
+*/
+func CompleteChallenges(c *pb.Character, names ...string) error {
+	class, _ := GetClass(c)
+	for _, name := range names {
+		challenge, ok := assets.FindChallenge(name, class.Name)
+		if !ok && !assets.IsAssetPath(name) {
+			return fmt.Errorf("unknown challenge: %s", name)
+		}
+		if !ok {
+			challenge.Path = name
+		}
+		completeChallenge(c, challenge.Path)
+	}
+	return nil
+}
+
+/*
+UnlockGearSlotChallenges completes the registered challenges that unlock equipment slots, see assets.GetSlotChallenges.
+Class specific challenges are skipped if the character's class is unknown.
+Returns an error if no such challenges are registered.
+*/
+func UnlockGearSlotChallenges(c *pb.Character) error {
+	if completeSlotChallenges(c) == 0 {
+		return fmt.Errorf("no challenges unlocking equipment slots known, they're read from the catalog")
+	}
+	return nil
+}
+
+// completeSlotChallenges completes the registered challenges that unlock equipment slots and returns their number.
+func completeSlotChallenges(c *pb.Character) int {
+	class, _ := GetClass(c)
+	challenges := assets.GetSlotChallenges(class.Name)
+	for _, challenge := range challenges {
+		completeChallenge(c, challenge.Path)
+	}
+	return len(challenges)
+}
+
+/*
+SetChallengeCategoryProgress sets the completion percentage shown for the challenge category at the given index.
+*/
+func SetChallengeCategoryProgress(c *pb.Character, category int, pct uint8) error {
+	if category < 0 {
+		return fmt.Errorf("invalid challenge category %d", category)
+	}
+	if pct > 100 {
+		return fmt.Errorf("invalid completion percentage %d", pct)
+	}
+	if c.ChallengeCategoryCompletionPcts == nil {
+		c.ChallengeCategoryCompletionPcts = &pb.ChallengeCategoryProgressSaveData{}
+	}
+	data := c.ChallengeCategoryCompletionPcts
+	for len(data.CategoryProgress) <= category {
+		data.CategoryProgress = append(data.CategoryProgress, 0)
+	}
+	data.CategoryProgress[category] = pct
+	return nil
+}
+
+/*
+UnlockEchoLogs adds the ECHO logs with the given friendly names or asset paths to the character's log,
+see assets.FindEchoLog. Newly added logs are marked as unseen, logs the character already has are skipped.
+*/
+func UnlockEchoLogs(c *pb.Character, names ...string) error {
+	for _, name := range names {
+		log, ok := assets.FindEchoLog(name)
+		if !ok {
+			return fmt.Errorf("unknown ECHO log: %s", name)
+		}
+		found := false
+		for _, unlocked := range c.UnlockedEchoLogs {
+			if unlocked.EchoLogPath == log.Path {
+				found = true
+				break
+			}
+		}
+		if !found {
+			c.UnlockedEchoLogs = append(c.UnlockedEchoLogs, &pb.EchoLogSaveGameData{EchoLogPath: log.Path})
+		}
+	}
+	return nil
+}
+
+/*
+UnlockAllEchoLogs adds all ECHO logs registered in assets.EchoLogs to the character's log, see UnlockEchoLogs.
+Returns an error if no ECHO logs are registered.
+*/
+func UnlockAllEchoLogs(c *pb.Character) error {
+	if len(assets.EchoLogs) == 0 {
+		return fmt.Errorf("no ECHO logs known, they're read from the catalog")
+	}
+	for _, l := range assets.EchoLogs {
+		if err := UnlockEchoLogs(c, l.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func completeChallenge(c *pb.Character, path string) {
+	for _, challenge := range c.ChallengeData {
+		if challenge.ChallengeClassPath == path {
+			challenge.IsActive = true
+			challenge.CurrentlyCompleted = true
+			if challenge.CompletedCount < 1 {
+				challenge.CompletedCount = 1
+			}
+			return
+		}
+	}
+	c.ChallengeData = append(c.ChallengeData, &pb.ChallengeSaveGameData{
+		CompletedCount:     1,
+		IsActive:           true,
+		CurrentlyCompleted: true,
+		ChallengeClassPath: path,
+	})
+}
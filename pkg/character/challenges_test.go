@@ -0,0 +1,85 @@
+package character
+
+import (
+	"testing"
+
+	"github.com/cfi2017/bl3-save-core/internal/testassets"
+	"github.com/cfi2017/bl3-save-core/pkg/assets"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+func TestCompleteChallenges(t *testing.T) {
+	defer testassets.Catalog()()
+	zane, _ := assets.FindClass("Zane")
+	c := &pb.Character{
+		PlayerClassData: &pb.PlayerClassSaveGameData{PlayerClassPath: zane.Path},
+		ChallengeData:   []*pb.ChallengeSaveGameData{{ChallengeClassPath: "/Game/Challenges/Other.Other_C"}},
+	}
+	custom := "/Game/GameData/Challenges/Custom.Custom_C"
+	if err := CompleteChallenges(c, "test class mod slot", custom, "/Game/Challenges/Other.Other_C"); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.ChallengeData) != 3 {
+		t.Fatalf("unexpected challenges %v", c.ChallengeData)
+	}
+	for _, challenge := range c.ChallengeData {
+		if !challenge.CurrentlyCompleted || challenge.CompletedCount != 1 {
+			t.Fatalf("challenge %s not completed", challenge.ChallengeClassPath)
+		}
+	}
+	if classMod, _ := assets.FindChallenge("Test Class Mod Slot", "Operative"); c.ChallengeData[1].ChallengeClassPath != classMod.Path {
+		t.Fatalf("expected the class mod challenge of the operative, got %s", c.ChallengeData[1].ChallengeClassPath)
+	}
+	if err := CompleteChallenges(c, "Mayhem Mode"); err == nil {
+		t.Fatal("expected error for unknown challenge")
+	}
+	if err := SetChallengeCategoryProgress(c, 2, 50); err != nil || c.ChallengeCategoryCompletionPcts.CategoryProgress[2] != 50 {
+		t.Fatal("category progress not set")
+	}
+	if err := SetChallengeCategoryProgress(c, 0, 101); err == nil {
+		t.Fatal("expected error for invalid percentage")
+	}
+}
+
+func TestUnlockGearSlotChallenges(t *testing.T) {
+	siren, _ := assets.FindClass("Siren")
+	c := &pb.Character{PlayerClassData: &pb.PlayerClassSaveGameData{PlayerClassPath: siren.Path}}
+	if err := UnlockGearSlotChallenges(c); err == nil {
+		t.Fatal("expected error without registered slot challenges")
+	}
+	defer testassets.Catalog()()
+	if err := UnlockGearSlotChallenges(c); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.ChallengeData) != 2 || c.ChallengeData[1].ChallengeClassPath != "/Test/Challenges/ClassMod_Siren.ClassMod_Siren_C" {
+		t.Fatalf("unexpected challenges %v", c.ChallengeData)
+	}
+	c = &pb.Character{}
+	if err := UnlockGearSlotChallenges(c); err != nil || len(c.ChallengeData) != 1 {
+		t.Fatalf("expected only the artifact challenge for an unknown class: %v", c.ChallengeData)
+	}
+}
+
+func TestUnlockEchoLogs(t *testing.T) {
+	c := &pb.Character{}
+	if err := UnlockAllEchoLogs(c); err == nil {
+		t.Fatal("expected error without registered ECHO logs")
+	}
+	log := "/Game/Missions/Plot/EchoLogs/EchoLog_Other.EchoLog_Other"
+	if err := UnlockEchoLogs(c, log, log); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.UnlockedEchoLogs) != 1 || c.UnlockedEchoLogs[0].EchoLogPath != log || c.UnlockedEchoLogs[0].HasBeenSeenInLog {
+		t.Fatalf("unexpected ECHO logs %v", c.UnlockedEchoLogs)
+	}
+	if err := UnlockEchoLogs(c, "Test ECHO Log"); err == nil {
+		t.Fatal("expected error for an unregistered name")
+	}
+	defer testassets.Catalog()()
+	if err := UnlockEchoLogs(c, "test echo log"); err != nil || len(c.UnlockedEchoLogs) != 2 || c.UnlockedEchoLogs[1].EchoLogPath != testassets.EchoLog {
+		t.Fatalf("ECHO log not unlocked by name: %v", err)
+	}
+	if err := UnlockAllEchoLogs(c); err != nil || len(c.UnlockedEchoLogs) != 2 {
+		t.Fatalf("unexpected ECHO logs %v", c.UnlockedEchoLogs)
+	}
+}
@@ -0,0 +1,136 @@
+package character
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cfi2017/bl3-save-core/pkg/assets"
+	"github.com/cfi2017/bl3-save-core/pkg/item"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+/*
+GetClass returns the class of the character as known by the class catalog.
+*/
+func GetClass(c *pb.Character) (assets.Class, bool) {
+	if c.PlayerClassData == nil {
+		return assets.Class{}, false
+	}
+	return assets.FindClass(c.PlayerClassData.PlayerClassPath)
+}
+
+// ClassChange reports the result of ChangeClass, including the changes that have to be made by hand.
+type ClassChange struct {
+	// Unusable holds the indexes into InventoryItems of items the new class can't use, e.g. class mods of the old class.
+	Unusable []int `json:"unusable"`
+	// KeptCustomizations holds the selected heads and skins that were kept because the new class has no known default.
+	KeptCustomizations []string `json:"keptCustomizations"`
+	// KeptEmotes is set if the character has equipped emotes. They are kept, the default emotes of the classes aren't known.
+	KeptEmotes bool `json:"keptEmotes"`
+}
+
+/*
+ChangeClass switches the character to another class, given by class or character name.
+All spent skill points are refunded and action skills and augments are cleared,
+the selected head and skin are replaced with the new class's defaults and unusable equipped items are unequipped.
+Cosmetics that can't be replaced and unusable items are reported in the result.
+Returns an error if the item database isn't available, items that can't be decoded are ignored.
+*/
+func ChangeClass(c *pb.Character, newClass string) (ClassChange, error) {
+	class, ok := assets.FindClass(newClass)
+	if !ok {
+		return ClassChange{}, fmt.Errorf("unknown class: %s", newClass)
+	}
+	result := ClassChange{Unusable: make([]int, 0)}
+	for i, data := range c.InventoryItems {
+		it, err := item.Decode(data.ItemSerialNumber)
+		var dbErr *item.DatabaseError
+		if errors.As(err, &dbErr) {
+			return ClassChange{}, err
+		}
+		if err != nil && it.Balance == "" {
+			continue
+		}
+		if owner, ok := classModClass(it.Balance); ok && owner.Name != class.Name {
+			result.Unusable = append(result.Unusable, i)
+		}
+	}
+	if c.PlayerClassData == nil {
+		c.PlayerClassData = &pb.PlayerClassSaveGameData{}
+	}
+	c.PlayerClassData.PlayerClassPath = class.Path
+
+	resetAbilities(c)
+	result.KeptCustomizations = resetClassCosmetics(c, class)
+	result.KeptEmotes = len(c.EquippedEmoteCustomizations) > 0
+
+	for _, e := range c.EquippedInventoryList {
+		for _, i := range result.Unusable {
+			if int(e.InventoryListIndex) == i {
+				e.InventoryListIndex = unequipped
+			}
+		}
+	}
+	return result, nil
+}
+
+// resetAbilities refunds all points spent in skill trees and clears the class specific ability data.
+func resetAbilities(c *pb.Character) {
+	var points int32
+	if c.AbilityData != nil {
+		points = c.AbilityData.AbilityPoints
+		for _, t := range c.AbilityData.TreeItemList {
+			points += t.Points
+		}
+	}
+	c.AbilityData = &pb.OakPlayerAbilitySaveGameData{AbilityPoints: points}
+}
+
+/*
+resetClassCosmetics replaces the selected head and skin with the defaults of the given class.
+Heads and skins of another class are kept if the class has no known default of that kind, they are returned.
+*/
+func resetClassCosmetics(c *pb.Character, class assets.Class) []string {
+	defaults := make(map[assets.CosmeticKind]assets.Cosmetic)
+	for _, cosmetic := range assets.Cosmetics {
+		if cosmetic.Class == class.Name && cosmetic.Default {
+			defaults[cosmetic.Kind] = cosmetic
+		}
+	}
+	kept := make([]string, 0)
+	selected := c.SelectedCustomizations[:0]
+	for _, path := range c.SelectedCustomizations {
+		kind := cosmeticKind(path)
+		if kind == assets.CosmeticHead || kind == assets.CosmeticSkin {
+			if _, ok := defaults[kind]; ok {
+				continue
+			}
+			if cosmetic, ok := assets.FindCosmetic(path); !ok || cosmetic.Class != class.Name {
+				kept = append(kept, path)
+			}
+		}
+		selected = append(selected, path)
+	}
+	for _, kind := range []assets.CosmeticKind{assets.CosmeticHead, assets.CosmeticSkin} {
+		if cosmetic, ok := defaults[kind]; ok {
+			selected = append(selected, cosmetic.Path)
+		}
+	}
+	c.SelectedCustomizations = selected
+	return kept
+}
+
+// classModClass returns the class a class mod balance belongs to, if the balance is a class mod.
+func classModClass(balance string) (assets.Class, bool) {
+	lower := strings.ToLower(balance)
+	if !strings.Contains(lower, "classmod") {
+		return assets.Class{}, false
+	}
+	for _, class := range assets.Classes {
+		if strings.Contains(lower, strings.ToLower(class.Name)) {
+			return class, true
+		}
+	}
+	return assets.Class{}, false
+}
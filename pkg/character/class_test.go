@@ -0,0 +1,64 @@
+package character
+
+import (
+	"testing"
+
+	"github.com/cfi2017/bl3-save-core/internal/testassets"
+	"github.com/cfi2017/bl3-save-core/pkg/assets"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+func TestChangeClass(t *testing.T) {
+	gunner, _ := assets.FindClass("Gunner")
+	head, _ := assets.FindCosmetic("/Game/PlayerCharacters/_Customizations/Gunner/Heads/CustomHead_Gunner_4.CustomHead_Gunner_4")
+	echo, _ := assets.FindCosmetic("Default ECHO theme")
+	c := &pb.Character{
+		PlayerClassData: &pb.PlayerClassSaveGameData{PlayerClassPath: gunner.Path},
+		AbilityData: &pb.OakPlayerAbilitySaveGameData{
+			AbilityPoints:   3,
+			TreeItemList:    []*pb.OakAbilityTreeItemSaveGameData{{Points: 5}, {Points: 2}},
+			AbilitySlotList: []*pb.OakAbilitySlotSaveGameData{{}},
+		},
+		SelectedCustomizations:      []string{head.Path, echo.Path},
+		EquippedEmoteCustomizations: []int32{1, 2},
+	}
+	result, err := ChangeClass(c, "Amara")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.KeptEmotes || len(result.KeptCustomizations) != 0 || len(c.EquippedEmoteCustomizations) != 2 {
+		t.Fatalf("unexpected result %v", result)
+	}
+	if class, ok := GetClass(c); !ok || class.Name != "Siren" {
+		t.Fatal("class not changed")
+	}
+	if c.AbilityData.AbilityPoints != 10 || len(c.AbilityData.TreeItemList) != 0 || len(c.AbilityData.AbilitySlotList) != 0 {
+		t.Fatal("skills not reset")
+	}
+	if err := ValidateCosmetics(c, &pb.Profile{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.SelectedCustomizations) != 3 || c.SelectedCustomizations[0] != echo.Path {
+		t.Fatalf("unexpected cosmetics %v", c.SelectedCustomizations)
+	}
+	if _, err := ChangeClass(c, "Krieg"); err == nil {
+		t.Fatal("expected error for unknown class")
+	}
+	restore := testassets.Load()
+	serial := testassets.Serial(testassets.Item(10), 1)
+	restore()
+	c.InventoryItems = []*pb.OakInventoryItemSaveGameData{{ItemSerialNumber: serial}}
+	if _, err := ChangeClass(c, "Moze"); err == nil {
+		t.Fatal("expected error without item database")
+	}
+	if class, _ := GetClass(c); class.Name != "Siren" {
+		t.Fatal("class changed despite error")
+	}
+	defer testassets.Load()()
+	if result, err := ChangeClass(c, "Moze"); err != nil || len(result.Unusable) != 0 {
+		t.Fatalf("unexpected result %v, %v", result, err)
+	}
+	if owner, ok := classModClass("/Game/Gear/ClassMods/_Design/BalDef_ClassMod_Gunner.BalDef_ClassMod_Gunner"); !ok || owner.Name != "Gunner" {
+		t.Fatal("class mod not detected")
+	}
+}
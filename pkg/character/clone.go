@@ -0,0 +1,84 @@
+package character
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	mrand "math/rand"
+	"path/filepath"
+	"strings"
+
+	"github.com/cfi2017/bl3-save-core/pkg/item"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+	"github.com/cfi2017/bl3-save-core/pkg/shared"
+	"google.golang.org/protobuf/proto"
+)
+
+/*
+Clone creates a copy of a character for a new save slot.
+The copy gets the new save game id and name, a new save game guid and no last save timestamp,
+so the game doesn't confuse it with the original.
+*/
+func Clone(src *pb.Character, newSlotID uint32, newName string) (*pb.Character, error) {
+	if newSlotID == 0 {
+		return nil, fmt.Errorf("invalid save game id %d", newSlotID)
+	}
+	guid, err := newSaveGameGuid()
+	if err != nil {
+		return nil, err
+	}
+	c := proto.Clone(src).(*pb.Character)
+	c.SaveGameId = newSlotID
+	c.SaveGameGuid = guid
+	c.LastSaveTimestamp = 0
+	if newName != "" {
+		c.PreferredCharacterName = newName
+	}
+	return c, nil
+}
+
+/*
+RerollItemSeeds encrypts all inventory items of a character with new random seeds.
+The items themselves are unchanged.
+*/
+func RerollItemSeeds(c *pb.Character) error {
+	for i, it := range c.InventoryItems {
+		serial, err := item.Reseed(it.ItemSerialNumber, mrand.Int31())
+		if err != nil {
+			return fmt.Errorf("item %d: %v", i, err)
+		}
+		it.ItemSerialNumber = serial
+	}
+	return nil
+}
+
+/*
+SaveFileName returns the file name the game uses for a character save, e.g. 1.sav.
+The game names character saves by their save game id in lowercase hexadecimal.
+*/
+func SaveFileName(id uint32) string {
+	return fmt.Sprintf("%x.sav", id)
+}
+
+/*
+WriteFile serializes a character into dir, using the file name belonging to its save game id.
+Returns the path of the written file.
+*/
+func WriteFile(dir string, s shared.SavFile, c *pb.Character, platform string) (string, error) {
+	buf := new(bytes.Buffer)
+	if err := Encode(buf, s, c, platform); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, SaveFileName(c.SaveGameId))
+	return path, ioutil.WriteFile(path, buf.Bytes(), 0644)
+}
+
+func newSaveGameGuid() (string, error) {
+	bs := make([]byte, 16)
+	if _, err := rand.Read(bs); err != nil {
+		return "", err
+	}
+	return strings.ToUpper(hex.EncodeToString(bs)), nil
+}
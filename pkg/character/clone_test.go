@@ -0,0 +1,47 @@
+package character
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cfi2017/bl3-save-core/pkg/item"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+func TestClone(t *testing.T) {
+	serial, err := item.EncryptSerial([]byte{0x80, 0x01, 0x02, 0x03}, 1234, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := &pb.Character{
+		SaveGameId:             1,
+		SaveGameGuid:           "0123456789ABCDEF0123456789ABCDEF",
+		LastSaveTimestamp:      1600000000,
+		PreferredCharacterName: "Moze",
+		InventoryItems:         []*pb.OakInventoryItemSaveGameData{{ItemSerialNumber: serial}},
+	}
+	c, err := Clone(src, 26, "Moze 2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.SaveGameId != 26 || c.PreferredCharacterName != "Moze 2" || c.LastSaveTimestamp != 0 {
+		t.Fatal("identity not changed")
+	}
+	if len(c.SaveGameGuid) != 32 || c.SaveGameGuid == src.SaveGameGuid {
+		t.Fatal("guid not changed")
+	}
+	if SaveFileName(c.SaveGameId) != "1a.sav" {
+		t.Fatalf("unexpected file name %s", SaveFileName(c.SaveGameId))
+	}
+	if err := RerollItemSeeds(c); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(src.InventoryItems[0].ItemSerialNumber, serial) {
+		t.Fatal("source character was modified")
+	}
+	before, _ := item.DecryptSerial(append([]byte{}, serial...))
+	after, err := item.DecryptSerial(append([]byte{}, c.InventoryItems[0].ItemSerialNumber...))
+	if err != nil || !bytes.Equal(before, after) {
+		t.Fatal("item changed by reseeding")
+	}
+}
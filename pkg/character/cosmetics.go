@@ -0,0 +1,138 @@
+package character
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cfi2017/bl3-save-core/pkg/assets"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+	"github.com/cfi2017/bl3-save-core/pkg/profile"
+)
+
+/*
+EquipCosmetic equips a head, skin or ECHO theme on the character, replacing the currently selected one of the same kind.
+The cosmetic must be usable by the character's class and unlocked on the given profile.
+*/
+func EquipCosmetic(c *pb.Character, p *pb.Profile, name string) error {
+	cosmetic, err := findUsableCosmetic(c, p, name)
+	if err != nil {
+		return err
+	}
+	switch cosmetic.Kind {
+	case assets.CosmeticHead, assets.CosmeticSkin, assets.CosmeticEchoTheme:
+	default:
+		return fmt.Errorf("cosmetic %s is a %s and can't be selected", cosmetic.Name, cosmetic.Kind)
+	}
+	for i, selected := range c.SelectedCustomizations {
+		if cosmeticKind(selected) == cosmetic.Kind {
+			c.SelectedCustomizations[i] = cosmetic.Path
+			return nil
+		}
+	}
+	c.SelectedCustomizations = append(c.SelectedCustomizations, cosmetic.Path)
+	return nil
+}
+
+/*
+EquipEmote equips an emote in the given zero-based emote slot, i.e. the index into EquippedEmoteCustomizations.
+The slot must exist in the save. The emote must have a known hash, be usable by the character's class
+and be unlocked on the given profile.
+*/
+func EquipEmote(c *pb.Character, p *pb.Profile, slot int, name string) error {
+	if slot < 0 || slot >= len(c.EquippedEmoteCustomizations) {
+		return fmt.Errorf("invalid emote slot %d, the character has %d", slot, len(c.EquippedEmoteCustomizations))
+	}
+	cosmetic, err := findUsableCosmetic(c, p, name)
+	if err != nil {
+		return err
+	}
+	if cosmetic.Kind != assets.CosmeticEmote {
+		return fmt.Errorf("cosmetic %s is not an emote", cosmetic.Name)
+	}
+	if cosmetic.Hash == 0 {
+		return fmt.Errorf("hash of emote %s not known", cosmetic.Name)
+	}
+	c.EquippedEmoteCustomizations[slot] = int32(cosmetic.Hash)
+	return nil
+}
+
+/*
+SetPlayerColor sets a custom player color for the given color parameter.
+A nil color or split color resets that color to the class default.
+*/
+func SetPlayerColor(c *pb.Character, parameter string, color, split *pb.Vec3) {
+	var data *pb.CustomPlayerColorSaveGameData
+	for _, d := range c.SelectedColorCustomizations {
+		if d.ColorParameter == parameter {
+			data = d
+			break
+		}
+	}
+	if data == nil {
+		data = &pb.CustomPlayerColorSaveGameData{ColorParameter: parameter}
+		c.SelectedColorCustomizations = append(c.SelectedColorCustomizations, data)
+	}
+	data.AppliedColor = color
+	data.UseDefaultColor = color == nil
+	data.SplitColor = split
+	data.UseDefaultSplitColor = split == nil
+}
+
+/*
+ValidateCosmetics checks that every known head, skin, ECHO theme and emote equipped on the character is usable
+by its class and unlocked on the profile. Customizations that aren't known are ignored.
+*/
+func ValidateCosmetics(c *pb.Character, p *pb.Profile) error {
+	for _, selected := range c.SelectedCustomizations {
+		if _, ok := assets.FindCosmetic(selected); !ok {
+			continue
+		}
+		if _, err := findUsableCosmetic(c, p, selected); err != nil {
+			return err
+		}
+	}
+	for _, hash := range c.EquippedEmoteCustomizations {
+		cosmetic, ok := assets.FindCosmeticByHash(uint32(hash))
+		if !ok {
+			continue
+		}
+		if _, err := findUsableCosmetic(c, p, cosmetic.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func findUsableCosmetic(c *pb.Character, p *pb.Profile, name string) (assets.Cosmetic, error) {
+	cosmetic, ok := assets.FindCosmetic(name)
+	if !ok {
+		return cosmetic, fmt.Errorf("unknown cosmetic: %s", name)
+	}
+	if cosmetic.Class != "" {
+		if class, ok := GetClass(c); ok && class.Name != cosmetic.Class {
+			return cosmetic, fmt.Errorf("cosmetic %s can't be used by class %s", cosmetic.Name, class.Name)
+		}
+	}
+	if !profile.IsCosmeticUnlocked(p, cosmetic) {
+		return cosmetic, fmt.Errorf("cosmetic %s is not unlocked", cosmetic.Name)
+	}
+	return cosmetic, nil
+}
+
+/*
+cosmeticKind determines the kind of a selected customization, falling back to its folder for cosmetics not in the catalog.
+*/
+func cosmeticKind(path string) assets.CosmeticKind {
+	if cosmetic, ok := assets.FindCosmetic(path); ok {
+		return cosmetic.Kind
+	}
+	switch {
+	case strings.Contains(path, "/Heads/"):
+		return assets.CosmeticHead
+	case strings.Contains(path, "/Skins/"):
+		return assets.CosmeticSkin
+	case strings.Contains(path, "/EchoDevice/"):
+		return assets.CosmeticEchoTheme
+	}
+	return ""
+}
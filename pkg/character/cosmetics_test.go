@@ -0,0 +1,83 @@
+package character
+
+import (
+	"testing"
+
+	"github.com/cfi2017/bl3-save-core/internal/testassets"
+	"github.com/cfi2017/bl3-save-core/pkg/assets"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+	"github.com/cfi2017/bl3-save-core/pkg/profile"
+)
+
+func TestEquipCosmetic(t *testing.T) {
+	siren, _ := assets.FindClass("Siren")
+	c := &pb.Character{
+		PlayerClassData:        &pb.PlayerClassSaveGameData{PlayerClassPath: siren.Path},
+		SelectedCustomizations: []string{"/Game/PlayerCharacters/_Customizations/SirenBrawler/Heads/CustomHead_Siren_Default.CustomHead_Siren_Default"},
+	}
+	p := &pb.Profile{}
+	head := "/Game/PlayerCharacters/_Customizations/SirenBrawler/Heads/CustomHead_Siren_5.CustomHead_Siren_5"
+	if err := EquipCosmetic(c, p, head); err == nil {
+		t.Fatal("expected error equipping a locked head")
+	}
+	cosmetic, ok := assets.FindCosmetic(head)
+	if !ok || cosmetic.Kind != assets.CosmeticHead || cosmetic.Class != "Siren" {
+		t.Fatalf("head not recognized: %v", cosmetic)
+	}
+	if err := profile.UnlockCosmetic(p, cosmetic); err != nil {
+		t.Fatal(err)
+	}
+	if err := EquipCosmetic(c, p, head); err != nil {
+		t.Fatal(err)
+	}
+	if err := EquipCosmetic(c, p, "Default ECHO theme"); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.SelectedCustomizations) != 2 || c.SelectedCustomizations[0] != head {
+		t.Fatalf("unexpected customizations %v", c.SelectedCustomizations)
+	}
+	if err := EquipCosmetic(c, p, "Moze default skin"); err == nil {
+		t.Fatal("expected error equipping a cosmetic of another class")
+	}
+	if err := ValidateCosmetics(c, p); err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateCosmetics(c, &pb.Profile{}); err == nil {
+		t.Fatal("expected error for a head that isn't unlocked")
+	}
+}
+
+func TestEquipEmote(t *testing.T) {
+	defer testassets.Catalog()()
+	siren, _ := assets.FindClass("Siren")
+	c := &pb.Character{
+		PlayerClassData:             &pb.PlayerClassSaveGameData{PlayerClassPath: siren.Path},
+		EquippedEmoteCustomizations: []int32{0, 0},
+	}
+	p := &pb.Profile{}
+	if err := EquipEmote(c, p, 1, "Test Siren Emote"); err == nil {
+		t.Fatal("expected error equipping a locked emote")
+	}
+	emote, _ := assets.FindCosmeticByHash(testassets.EmoteHash)
+	if err := profile.UnlockCosmetic(p, emote); err != nil {
+		t.Fatal(err)
+	}
+	if err := EquipEmote(c, p, 2, emote.Name); err == nil {
+		t.Fatal("expected error for a slot missing from the save")
+	}
+	if err := EquipEmote(c, p, 1, "Test Weapon Trinket"); err == nil {
+		t.Fatal("expected error equipping a trinket as emote")
+	}
+	if err := EquipEmote(c, p, 1, emote.Name); err != nil {
+		t.Fatal(err)
+	}
+	if c.EquippedEmoteCustomizations[1] != testassets.EmoteHash {
+		t.Fatalf("emote not equipped: %v", c.EquippedEmoteCustomizations)
+	}
+	if err := ValidateCosmetics(c, p); err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateCosmetics(c, &pb.Profile{}); err == nil {
+		t.Fatal("expected error for an emote that isn't unlocked")
+	}
+}
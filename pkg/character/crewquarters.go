@@ -0,0 +1,130 @@
+package character
+
+import (
+	"fmt"
+
+	"github.com/cfi2017/bl3-save-core/pkg/assets"
+	"github.com/cfi2017/bl3-save-core/pkg/item"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+	"github.com/cfi2017/bl3-save-core/pkg/profile"
+)
+
+/*
+SetCrewQuartersRoom selects the crew quarters room by friendly name or path.
+If a profile is given, the room must be unlocked on it.
+*/
+func SetCrewQuartersRoom(c *pb.Character, p *pb.Profile, name string) error {
+	room, ok := assets.FindCrewQuartersRoom(name)
+	if !ok {
+		return fmt.Errorf("unknown crew quarters room: %s", name)
+	}
+	if p != nil && !profile.IsCrewQuartersRoomUnlocked(p, room.Path) {
+		return fmt.Errorf("crew quarters room %s is not unlocked", room.Name)
+	}
+	ensureCrewQuarters(c)
+	c.CrewQuartersRoom.RoomDataPath = room.Path
+	return nil
+}
+
+/*
+PlaceDecoration places a decoration, given by friendly name or path, at the given decoration index in the crew quarters.
+An empty name removes the decoration at that index. If a profile is given, the decoration must be unlocked on it.
+*/
+func PlaceDecoration(c *pb.Character, p *pb.Profile, index int, name string) error {
+	if index < 0 {
+		return fmt.Errorf("invalid decoration index %d", index)
+	}
+	ensureCrewQuarters(c)
+	if name == "" {
+		decorations := c.CrewQuartersRoom.Decorations[:0]
+		for _, d := range c.CrewQuartersRoom.Decorations {
+			if int(d.DecorationIndex) != index {
+				decorations = append(decorations, d)
+			}
+		}
+		c.CrewQuartersRoom.Decorations = decorations
+		return nil
+	}
+	decoration, ok := assets.FindCrewQuartersDecoration(name)
+	if !ok {
+		return fmt.Errorf("unknown crew quarters decoration: %s", name)
+	}
+	if p != nil && !profile.IsCrewQuartersDecorationUnlocked(p, decoration.Path) {
+		return fmt.Errorf("crew quarters decoration %s is not unlocked", decoration.Name)
+	}
+	for _, d := range c.CrewQuartersRoom.Decorations {
+		if int(d.DecorationIndex) == index {
+			d.DecorationDataPath = decoration.Path
+			return nil
+		}
+	}
+	c.CrewQuartersRoom.Decorations = append(c.CrewQuartersRoom.Decorations, &pb.CrewQuartersDecorationSaveData{
+		DecorationIndex:    int32(index),
+		DecorationDataPath: decoration.Path,
+	})
+	return nil
+}
+
+/*
+GetGunRack returns the serials of the items on the crew quarters gun rack, by rack slot asset path.
+*/
+func GetGunRack(c *pb.Character) map[string][]byte {
+	rack := make(map[string][]byte)
+	if c.CrewQuartersGunRack == nil {
+		return rack
+	}
+	for _, i := range c.CrewQuartersGunRack.RackSaveData {
+		rack[i.SlotAssetPath] = i.EncryptedSerialNumber
+	}
+	return rack
+}
+
+/*
+PlaceOnGunRack puts the item with the given serial into a slot of the crew quarters gun rack, replacing the item in it.
+The serial must be a valid item serial, it isn't decoded and no database is required.
+*/
+func PlaceOnGunRack(c *pb.Character, slot string, serial []byte) error {
+	if slot == "" {
+		return fmt.Errorf("gun rack slot must not be empty")
+	}
+	if _, err := item.DecryptSerial(serial); err != nil {
+		return fmt.Errorf("invalid serial: %v", err)
+	}
+	if c.CrewQuartersGunRack == nil {
+		c.CrewQuartersGunRack = &pb.CrewQuartersGunRackSaveData{}
+	}
+	for _, i := range c.CrewQuartersGunRack.RackSaveData {
+		if i.SlotAssetPath == slot {
+			// the development data belongs to the replaced item
+			i.EncryptedSerialNumber, i.DevelopmentSaveData = serial, nil
+			return nil
+		}
+	}
+	c.CrewQuartersGunRack.RackSaveData = append(c.CrewQuartersGunRack.RackSaveData, &pb.CrewQuartersGunRackItemSaveData{
+		EncryptedSerialNumber: serial,
+		SlotAssetPath:         slot,
+	})
+	return nil
+}
+
+/*
+RemoveFromGunRack takes the item out of a slot of the crew quarters gun rack and returns its serial.
+*/
+func RemoveFromGunRack(c *pb.Character, slot string) ([]byte, error) {
+	if c.CrewQuartersGunRack != nil {
+		for index, i := range c.CrewQuartersGunRack.RackSaveData {
+			if i.SlotAssetPath == slot {
+				list := c.CrewQuartersGunRack.RackSaveData
+				c.CrewQuartersGunRack.RackSaveData = append(list[:index], list[index+1:]...)
+				return i.EncryptedSerialNumber, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("gun rack slot %s is empty", slot)
+}
+
+func ensureCrewQuarters(c *pb.Character) {
+	if c.CrewQuartersRoom == nil {
+		c.CrewQuartersRoom = &pb.CrewQuartersSaveData{}
+	}
+}
@@ -0,0 +1,92 @@
+package character
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cfi2017/bl3-save-core/internal/testassets"
+	"github.com/cfi2017/bl3-save-core/pkg/item"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+	"github.com/cfi2017/bl3-save-core/pkg/profile"
+)
+
+func TestCrewQuarters(t *testing.T) {
+	c, p := &pb.Character{}, &pb.Profile{}
+	room := "/Game/Pickups/CrewQuarters/Room_Test.Room_Test"
+	decoration := "/Game/Pickups/CrewQuarters/Deco_Test.Deco_Test"
+	if err := SetCrewQuartersRoom(c, p, room); err == nil {
+		t.Fatal("expected error for a locked room")
+	}
+	if err := profile.UnlockCrewQuartersRooms(p, room); err != nil {
+		t.Fatal(err)
+	}
+	if err := profile.UnlockCrewQuartersDecorations(p, decoration, decoration); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.UnlockedCrewQuartersDecorations) != 1 || !p.UnlockedCrewQuartersDecorations[0].IsNew {
+		t.Fatalf("unexpected decorations %v", p.UnlockedCrewQuartersDecorations)
+	}
+	if err := SetCrewQuartersRoom(c, p, room); err != nil || c.CrewQuartersRoom.RoomDataPath != room {
+		t.Fatalf("room not set: %v", err)
+	}
+	for _, index := range []int{0, 3, 3} {
+		if err := PlaceDecoration(c, p, index, decoration); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := PlaceDecoration(c, p, 0, ""); err != nil {
+		t.Fatal(err)
+	}
+	if d := c.CrewQuartersRoom.Decorations; len(d) != 1 || d[0].DecorationIndex != 3 {
+		t.Fatalf("unexpected decorations %v", d)
+	}
+	if err := PlaceDecoration(c, p, 1, "Test Decoration"); err == nil {
+		t.Fatal("expected error for an unregistered decoration")
+	}
+}
+
+func TestCrewQuartersByName(t *testing.T) {
+	defer testassets.Catalog()()
+	c, p := &pb.Character{}, &pb.Profile{}
+	if err := profile.UnlockCrewQuartersRooms(p, "test room"); err != nil {
+		t.Fatal(err)
+	}
+	if err := profile.UnlockCrewQuartersDecorations(p, "Test Decoration"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetCrewQuartersRoom(c, p, "Test Room"); err != nil || c.CrewQuartersRoom.RoomDataPath != testassets.Room {
+		t.Fatalf("room not set by name: %v", err)
+	}
+	if err := PlaceDecoration(c, p, 2, "test decoration"); err != nil {
+		t.Fatal(err)
+	}
+	if d := c.CrewQuartersRoom.Decorations; len(d) != 1 || d[0].DecorationDataPath != testassets.Decoration {
+		t.Fatalf("unexpected decorations %v", d)
+	}
+}
+
+func TestGunRack(t *testing.T) {
+	c := &pb.Character{}
+	first, _ := item.EncryptSerial([]byte{0x80, 1, 2}, 1, 3)
+	second, _ := item.EncryptSerial([]byte{0x80, 3, 4}, 2, 3)
+	if err := PlaceOnGunRack(c, "Slot1", first); err != nil {
+		t.Fatal(err)
+	}
+	c.CrewQuartersGunRack.RackSaveData[0].DevelopmentSaveData = &pb.InventoryBalanceStateInitializationData{}
+	if err := PlaceOnGunRack(c, "Slot1", second); err != nil {
+		t.Fatal(err)
+	}
+	if err := PlaceOnGunRack(c, "Slot2", []byte{0x03, 1}); err == nil {
+		t.Fatal("expected error for an invalid serial")
+	}
+	rack := GetGunRack(c)
+	if len(rack) != 1 || !bytes.Equal(rack["Slot1"], second) || c.CrewQuartersGunRack.RackSaveData[0].DevelopmentSaveData != nil {
+		t.Fatalf("unexpected gun rack %v", c.CrewQuartersGunRack)
+	}
+	if serial, err := RemoveFromGunRack(c, "Slot1"); err != nil || !bytes.Equal(serial, second) || len(GetGunRack(c)) != 0 {
+		t.Fatalf("item not removed: %v", err)
+	}
+	if _, err := RemoveFromGunRack(c, "Slot1"); err == nil {
+		t.Fatal("expected error for an empty slot")
+	}
+}
@@ -0,0 +1,14 @@
+package character
+
+import (
+	"github.com/cfi2017/bl3-save-core/pkg/diff"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+/*
+Diff returns the differences between two characters, e.g. a backup and the current save.
+Items are compared by their decoded content, see diff.Messages.
+*/
+func Diff(a, b *pb.Character) []diff.Change {
+	return diff.Messages(a, b)
+}
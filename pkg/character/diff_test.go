@@ -0,0 +1,93 @@
+package character
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cfi2017/bl3-save-core/internal/testassets"
+	"github.com/cfi2017/bl3-save-core/pkg/item"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+func TestDiff(t *testing.T) {
+	serial := func(data []byte, seed int32) []byte {
+		s, err := item.EncryptSerial(data, seed, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	mission := func(status pb.MissionStatusPlayerSaveGameData_MissionState) []*pb.MissionPlaythroughSaveGameData {
+		return []*pb.MissionPlaythroughSaveGameData{{}, {MissionList: []*pb.MissionStatusPlayerSaveGameData{{Status: status}}}}
+	}
+	a := &pb.Character{
+		ExperiencePoints:        100,
+		MissionPlaythroughsData: mission(pb.MissionStatusPlayerSaveGameData_MS_Active),
+		InventoryItems: []*pb.OakInventoryItemSaveGameData{
+			{ItemSerialNumber: serial([]byte{0x80, 1}, 1)},
+			{ItemSerialNumber: serial([]byte{0x80, 2}, 1)},
+		},
+	}
+	b := &pb.Character{
+		ExperiencePoints:        100,
+		MissionPlaythroughsData: mission(pb.MissionStatusPlayerSaveGameData_MS_Complete),
+		InventoryItems: []*pb.OakInventoryItemSaveGameData{
+			// same item with a different seed
+			{ItemSerialNumber: serial([]byte{0x80, 2}, 7), Flags: 1},
+		},
+	}
+	var got []string
+	for _, c := range Diff(a, b) {
+		got = append(got, c.String())
+	}
+	want := []string{
+		"MissionPlaythroughsData[1].MissionList[0].Status: MS_Active -> MS_Complete",
+		"InventoryItems[0]: item ",
+		"InventoryItems[0].Flags: 0 -> 1",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected changes:\n%s", strings.Join(got, "\n"))
+	}
+	for _, w := range want {
+		found := false
+		for _, g := range got {
+			found = found || strings.HasPrefix(g, w)
+		}
+		if !found {
+			t.Errorf("missing change %s in:\n%s", w, strings.Join(got, "\n"))
+		}
+	}
+	if len(Diff(a, a)) != 0 {
+		t.Error("expected no changes comparing a character to itself")
+	}
+}
+
+func TestDiffDecodedItems(t *testing.T) {
+	defer testassets.Load()()
+	changed := testassets.Item(10)
+	changed.Level = 20
+	a := &pb.Character{
+		InventoryItems: []*pb.OakInventoryItemSaveGameData{
+			{ItemSerialNumber: testassets.Serial(testassets.Item(10), 1)},
+			{ItemSerialNumber: testassets.Serial(testassets.Item(30), 1)},
+		},
+	}
+	b := &pb.Character{
+		InventoryItems: []*pb.OakInventoryItemSaveGameData{
+			// re-seeded, the decoded content is unchanged
+			{ItemSerialNumber: testassets.Serial(testassets.Item(10), 5)},
+			{ItemSerialNumber: testassets.Serial(changed, 1)},
+		},
+	}
+	var got []string
+	for _, c := range Diff(a, b) {
+		got = append(got, c.String())
+	}
+	want := []string{
+		"InventoryItems[1]: Balance_AR_VLA_05_Legendary level 30 -> (none)",
+		"InventoryItems[1]: (none) -> Balance_AR_VLA_05_Legendary level 20",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected changes:\n%s", strings.Join(got, "\n"))
+	}
+}
@@ -0,0 +1,173 @@
+package character
+
+import (
+	"fmt"
+
+	"github.com/cfi2017/bl3-save-core/pkg/assets"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+// unequipped is the inventory list index of an empty equipment slot.
+const unequipped = -1
+
+/*
+UnlockAllSlots enables all equipment slots listed in the character's EquippedInventoryList and marks the unlocks as seen.
+Slots are only known by the paths the save already lists, slots missing from it can't be added.
+The challenges gating slots are completed if they're registered, see UnlockGearSlotChallenges.
+*/
+func UnlockAllSlots(c *pb.Character) error {
+	completeSlotChallenges(c)
+	if c.UiTrackingSaveGameData == nil {
+		c.UiTrackingSaveGameData = &pb.UITrackingSaveGameData{}
+	}
+	for _, e := range c.EquippedInventoryList {
+		e.Enabled = true
+		if !containsString(c.UiTrackingSaveGameData.InventorySlotUnlocksSeen, e.SlotDataPath) {
+			c.UiTrackingSaveGameData.InventorySlotUnlocksSeen = append(c.UiTrackingSaveGameData.InventorySlotUnlocksSeen, e.SlotDataPath)
+		}
+	}
+	return nil
+}
+
+/*
+GetEquipped returns the index into InventoryItems of the item equipped in each slot, by slot name.
+Empty slots are omitted. See assets.ParseInventorySlot for how slots are named.
+*/
+func GetEquipped(c *pb.Character) map[string]int {
+	equipped := make(map[string]int)
+	for _, e := range c.EquippedInventoryList {
+		if e.InventoryListIndex < 0 {
+			continue
+		}
+		equipped[assets.ParseInventorySlot(e.SlotDataPath).Name] = int(e.InventoryListIndex)
+	}
+	return equipped
+}
+
+/*
+EquipItem equips the inventory item at the given index into the named slot (e.g. weapon1, artifact).
+The slot must be listed in the character's EquippedInventoryList and enabled. An item can only be equipped in one slot, it's removed from any other slot first.
+ActiveWeaponList is updated if the held weapon's slot was emptied that way, see UpdateActiveWeapons.
+*/
+func EquipItem(c *pb.Character, slot string, index int) error {
+	data, err := findSlot(c, slot)
+	if err != nil {
+		return err
+	}
+	if index < 0 || index >= len(c.InventoryItems) {
+		return fmt.Errorf("invalid inventory index %d", index)
+	}
+	if !data.Enabled {
+		return fmt.Errorf("inventory slot %s is not unlocked", slot)
+	}
+	for _, e := range c.EquippedInventoryList {
+		if int(e.InventoryListIndex) == index {
+			e.InventoryListIndex = unequipped
+		}
+	}
+	data.InventoryListIndex = int32(index)
+	UpdateActiveWeapons(c)
+	return nil
+}
+
+/*
+UnequipSlot empties the named slot and updates ActiveWeaponList, see UpdateActiveWeapons.
+*/
+func UnequipSlot(c *pb.Character, slot string) error {
+	data, err := findSlot(c, slot)
+	if err != nil {
+		return err
+	}
+	data.InventoryListIndex = unequipped
+	UpdateActiveWeapons(c)
+	return nil
+}
+
+/*
+UpdateActiveWeapons makes sure ActiveWeaponList, the indexes into EquippedInventoryList of the held weapons,
+only references weapon slots that have an item equipped.
+Entries referencing an invalid or empty slot are replaced by another equipped weapon slot that isn't held yet,
+or set to -1 if there is none. Entries that already are -1 are left as they are.
+*/
+func UpdateActiveWeapons(c *pb.Character) {
+	valid := func(i int32) bool {
+		if i < 0 || int(i) >= len(c.EquippedInventoryList) {
+			return false
+		}
+		e := c.EquippedInventoryList[i]
+		return assets.ParseInventorySlot(e.SlotDataPath).Weapon && e.InventoryListIndex >= 0
+	}
+	held := func(i int32) bool {
+		for _, a := range c.ActiveWeaponList {
+			if a == i {
+				return true
+			}
+		}
+		return false
+	}
+	for i, active := range c.ActiveWeaponList {
+		if active == unequipped || valid(active) {
+			continue
+		}
+		c.ActiveWeaponList[i] = unequipped
+		for j := range c.EquippedInventoryList {
+			if valid(int32(j)) && !held(int32(j)) {
+				c.ActiveWeaponList[i] = int32(j)
+				break
+			}
+		}
+	}
+}
+
+/*
+InsertInventoryItem inserts an item into InventoryItems at the given index and shifts the indexes
+of equipped items behind it so they keep referencing the same items.
+ActiveWeaponList references equipment slots rather than items and stays valid.
+*/
+func InsertInventoryItem(c *pb.Character, index int, item *pb.OakInventoryItemSaveGameData) error {
+	if index < 0 || index > len(c.InventoryItems) {
+		return fmt.Errorf("invalid inventory index %d", index)
+	}
+	c.InventoryItems = append(c.InventoryItems, nil)
+	copy(c.InventoryItems[index+1:], c.InventoryItems[index:])
+	c.InventoryItems[index] = item
+	for _, e := range c.EquippedInventoryList {
+		if int(e.InventoryListIndex) >= index {
+			e.InventoryListIndex++
+		}
+	}
+	return nil
+}
+
+/*
+RemoveInventoryItem removes the item at the given index from InventoryItems.
+The item is unequipped if necessary and the indexes of equipped items behind it are shifted to keep referencing the same items.
+ActiveWeaponList is updated if the removed item was a held weapon, see UpdateActiveWeapons.
+*/
+func RemoveInventoryItem(c *pb.Character, index int) (*pb.OakInventoryItemSaveGameData, error) {
+	if index < 0 || index >= len(c.InventoryItems) {
+		return nil, fmt.Errorf("invalid inventory index %d", index)
+	}
+	item := c.InventoryItems[index]
+	c.InventoryItems = append(c.InventoryItems[:index], c.InventoryItems[index+1:]...)
+	for _, e := range c.EquippedInventoryList {
+		switch {
+		case int(e.InventoryListIndex) == index:
+			e.InventoryListIndex = unequipped
+		case int(e.InventoryListIndex) > index:
+			e.InventoryListIndex--
+		}
+	}
+	UpdateActiveWeapons(c)
+	return item, nil
+}
+
+// findSlot returns the equipped inventory entry for the named slot, see assets.InventorySlot.Match.
+func findSlot(c *pb.Character, name string) (*pb.EquippedInventorySaveGameData, error) {
+	for _, e := range c.EquippedInventoryList {
+		if assets.ParseInventorySlot(e.SlotDataPath).Match(name) {
+			return e, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown inventory slot: %s", name)
+}
@@ -0,0 +1,134 @@
+package character
+
+import (
+	"testing"
+
+	"github.com/cfi2017/bl3-save-core/pkg/assets"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+// slotPaths are the SlotDataPaths of the equipment slots of the test characters.
+var slotPaths = []string{
+	"/Test/BPInvSlot_Weapon1.BPInvSlot_Weapon1",
+	"/Test/BPInvSlot_Weapon2.BPInvSlot_Weapon2",
+	"/Test/BPInvSlot_Weapon3.BPInvSlot_Weapon3",
+	"/Test/BPInvSlot_Shield.BPInvSlot_Shield",
+	"/Test/BPInvSlot_Artifact.BPInvSlot_Artifact",
+}
+
+func slotsCharacter(items int) *pb.Character {
+	c := &pb.Character{}
+	for i := 0; i < items; i++ {
+		c.InventoryItems = append(c.InventoryItems, &pb.OakInventoryItemSaveGameData{PickupOrderIndex: int32(i)})
+	}
+	for _, path := range slotPaths {
+		c.EquippedInventoryList = append(c.EquippedInventoryList, &pb.EquippedInventorySaveGameData{
+			InventoryListIndex: -1,
+			SlotDataPath:       path,
+		})
+	}
+	return c
+}
+
+func TestParseInventorySlot(t *testing.T) {
+	for path, expected := range map[string]assets.InventorySlot{
+		slotPaths[0]:         {Name: "weapon1", Path: slotPaths[0], Weapon: true},
+		slotPaths[3]:         {Name: "shield", Path: slotPaths[3]},
+		"/Test/Slot.Slot":    {Name: "/Test/Slot.Slot", Path: "/Test/Slot.Slot"},
+		"/Test/BPInvSlot_.x": {Name: "/Test/BPInvSlot_.x", Path: "/Test/BPInvSlot_.x"},
+	} {
+		if slot := assets.ParseInventorySlot(path); slot != expected {
+			t.Errorf("%s: expected %v, got %v", path, expected, slot)
+		}
+	}
+}
+
+func TestEquipmentRemapping(t *testing.T) {
+	c := slotsCharacter(3)
+	if err := EquipItem(c, "artifact", 0); err == nil {
+		t.Fatal("expected error for locked slot")
+	}
+	if err := EquipItem(c, "classmod", 0); err == nil {
+		t.Fatal("expected error for slot missing from the save")
+	}
+	if err := UnlockAllSlots(c); err != nil {
+		t.Fatal(err)
+	}
+	if err := EquipItem(c, "weapon1", 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := EquipItem(c, "artifact", 2); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := InsertInventoryItem(c, 0, &pb.OakInventoryItemSaveGameData{PickupOrderIndex: 3}); err != nil {
+		t.Fatal(err)
+	}
+	equipped := GetEquipped(c)
+	if equipped["weapon1"] != 2 || equipped["artifact"] != 3 {
+		t.Fatalf("equipped indexes not shifted after insert: %v", equipped)
+	}
+
+	if _, err := RemoveInventoryItem(c, 2); err != nil {
+		t.Fatal(err)
+	}
+	equipped = GetEquipped(c)
+	if _, ok := equipped["weapon1"]; ok {
+		t.Fatal("removed item is still equipped")
+	}
+	if equipped["artifact"] != 2 || c.InventoryItems[2].PickupOrderIndex != 2 {
+		t.Fatalf("equipped indexes not shifted after remove: %v", equipped)
+	}
+}
+
+func TestActiveWeapons(t *testing.T) {
+	c := slotsCharacter(3)
+	if err := UnlockAllSlots(c); err != nil {
+		t.Fatal(err)
+	}
+	slotIndex := func(name string) int32 {
+		for i, e := range c.EquippedInventoryList {
+			if assets.ParseInventorySlot(e.SlotDataPath).Name == name {
+				return int32(i)
+			}
+		}
+		t.Fatalf("slot %s not found", name)
+		return 0
+	}
+	for name, index := range map[string]int{"weapon1": 0, "weapon2": 1, "shield": 2} {
+		if err := EquipItem(c, name, index); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c.ActiveWeaponList = []int32{slotIndex("weapon1"), -1}
+
+	// inserting items doesn't move equipment slots
+	if err := InsertInventoryItem(c, 0, &pb.OakInventoryItemSaveGameData{}); err != nil {
+		t.Fatal(err)
+	}
+	if c.ActiveWeaponList[0] != slotIndex("weapon1") || c.ActiveWeaponList[1] != -1 {
+		t.Fatalf("active weapons changed after insert: %v", c.ActiveWeaponList)
+	}
+
+	// emptying the held slot switches to the first other equipped weapon
+	if err := EquipItem(c, "weapon3", 1); err != nil {
+		t.Fatal(err)
+	}
+	if c.ActiveWeaponList[0] != slotIndex("weapon2") || c.ActiveWeaponList[1] != -1 {
+		t.Fatalf("active weapons not updated after equip: %v", c.ActiveWeaponList)
+	}
+
+	// removing the held weapon switches to the remaining equipped weapon, never to the shield
+	if _, err := RemoveInventoryItem(c, 2); err != nil {
+		t.Fatal(err)
+	}
+	if c.ActiveWeaponList[0] != slotIndex("weapon3") {
+		t.Fatalf("active weapons not updated after remove: %v", c.ActiveWeaponList)
+	}
+	if err := UnequipSlot(c, "weapon3"); err != nil {
+		t.Fatal(err)
+	}
+	if c.ActiveWeaponList[0] != -1 || c.ActiveWeaponList[1] != -1 {
+		t.Fatalf("active weapons reference empty slots: %v", c.ActiveWeaponList)
+	}
+}
@@ -0,0 +1,185 @@
+package character
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cfi2017/bl3-save-core/pkg/assets"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+	"github.com/cfi2017/bl3-save-core/pkg/profile"
+)
+
+const (
+	flagSeen     = 0x01
+	flagFavorite = 0x02
+	flagJunk     = 0x04
+)
+
+// ErrBackpackFull is returned when an item is added to a character whose backpack has no free slots.
+var ErrBackpackFull = errors.New("backpack is full")
+
+// ItemFlags are the user visible flags of an inventory item.
+type ItemFlags struct {
+	Seen     bool `json:"seen"`
+	Favorite bool `json:"favorite"`
+	Junk     bool `json:"junk"`
+}
+
+/*
+GetItemFlags returns the flags of an inventory item.
+*/
+func GetItemFlags(i *pb.OakInventoryItemSaveGameData) ItemFlags {
+	return ItemFlags{
+		Seen:     i.Flags&flagSeen != 0,
+		Favorite: i.Flags&flagFavorite != 0,
+		Junk:     i.Flags&flagJunk != 0,
+	}
+}
+
+/*
+SetItemFlags sets the flags of an inventory item. Flag bits unknown to this library are preserved.
+*/
+func SetItemFlags(i *pb.OakInventoryItemSaveGameData, f ItemFlags) {
+	flags := i.Flags &^ (flagSeen | flagFavorite | flagJunk)
+	if f.Seen {
+		flags |= flagSeen
+	}
+	if f.Favorite {
+		flags |= flagFavorite
+	}
+	if f.Junk {
+		flags |= flagJunk
+	}
+	i.Flags = flags
+}
+
+/*
+BackpackSize returns the number of backpack slots granted by the character's SDUs, or -1 if it isn't known.
+*/
+func BackpackSize(c *pb.Character) int {
+	sdu, _ := assets.FindSDU(assets.SDUBackpack)
+	return sdu.Capacity(c.SduList)
+}
+
+/*
+SetSDU sets the level of a character SDU, given by its name or path.
+Bank and lost loot SDUs are stored in the profile, see profile.SetSDU.
+*/
+func SetSDU(c *pb.Character, name string, level int) error {
+	sdu, ok := assets.FindSDU(name)
+	if !ok {
+		return fmt.Errorf("unknown SDU %s", name)
+	}
+	if sdu.Profile {
+		return fmt.Errorf("SDU %s is stored in the profile", sdu.Name)
+	}
+	list, err := sdu.SetLevel(c.SduList, level)
+	if err != nil {
+		return err
+	}
+	c.SduList = list
+	return nil
+}
+
+/*
+MaxSDUs sets all character SDUs to their highest level.
+Returns an error without changing the character if the highest level of an SDU isn't known.
+*/
+func MaxSDUs(c *pb.Character) error {
+	if err := assets.CheckSDUMaxLevels(false); err != nil {
+		return err
+	}
+	for _, sdu := range assets.SDUs {
+		if !sdu.Profile {
+			c.SduList, _ = sdu.SetLevel(c.SduList, sdu.MaxLevel)
+		}
+	}
+	return nil
+}
+
+/*
+BackpackUsage returns the number of items in the backpack. Equipped items don't take up backpack space.
+*/
+func BackpackUsage(c *pb.Character) int {
+	used := len(c.InventoryItems)
+	for _, e := range c.EquippedInventoryList {
+		if e.InventoryListIndex >= 0 && int(e.InventoryListIndex) < len(c.InventoryItems) {
+			used--
+		}
+	}
+	return used
+}
+
+/*
+AddItem adds an item serial to the character's inventory as the most recently picked up item and returns its index.
+Returns ErrBackpackFull if the backpack has no free slots. The backpack size is only checked if it's known.
+*/
+func AddItem(c *pb.Character, serial []byte, flags ItemFlags) (int, error) {
+	if size := BackpackSize(c); size >= 0 && BackpackUsage(c) >= size {
+		return 0, ErrBackpackFull
+	}
+	item := &pb.OakInventoryItemSaveGameData{
+		ItemSerialNumber: serial,
+		PickupOrderIndex: nextPickupOrderIndex(c),
+	}
+	SetItemFlags(item, flags)
+	index := len(c.InventoryItems)
+	if err := InsertInventoryItem(c, index, item); err != nil {
+		return 0, err
+	}
+	return index, nil
+}
+
+/*
+RemoveItem removes the item at the given index from the character's inventory and returns its serial.
+*/
+func RemoveItem(c *pb.Character, index int) ([]byte, error) {
+	item, err := RemoveInventoryItem(c, index)
+	if err != nil {
+		return nil, err
+	}
+	return item.ItemSerialNumber, nil
+}
+
+/*
+MoveItemToBank moves the item at the given inventory index to the end of the profile's bank.
+Returns profile.ErrBankFull without touching the inventory if the bank has no free slots.
+The bank size is only checked if it's known.
+*/
+func MoveItemToBank(c *pb.Character, p *pb.Profile, index int) error {
+	if size := profile.BankSize(p); size >= 0 && len(p.BankInventoryList) >= size {
+		return profile.ErrBankFull
+	}
+	serial, err := RemoveItem(c, index)
+	if err != nil {
+		return err
+	}
+	p.BankInventoryList = append(p.BankInventoryList, serial)
+	return nil
+}
+
+/*
+MoveBankItemToCharacter moves the bank item at the given index into the character's backpack and returns its inventory index.
+The item is marked as seen. Returns ErrBackpackFull without touching the bank if the backpack has no free slots.
+*/
+func MoveBankItemToCharacter(p *pb.Profile, c *pb.Character, index int) (int, error) {
+	if index < 0 || index >= len(p.BankInventoryList) {
+		return 0, fmt.Errorf("invalid bank index %d", index)
+	}
+	i, err := AddItem(c, p.BankInventoryList[index], ItemFlags{Seen: true})
+	if err != nil {
+		return 0, err
+	}
+	p.BankInventoryList = append(p.BankInventoryList[:index], p.BankInventoryList[index+1:]...)
+	return i, nil
+}
+
+func nextPickupOrderIndex(c *pb.Character) int32 {
+	var next int32
+	for _, i := range c.InventoryItems {
+		if i.PickupOrderIndex >= next {
+			next = i.PickupOrderIndex + 1
+		}
+	}
+	return next
+}
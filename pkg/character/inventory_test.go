@@ -0,0 +1,83 @@
+package character
+
+import (
+	"testing"
+
+	"github.com/cfi2017/bl3-save-core/internal/testassets"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+func TestAddItem(t *testing.T) {
+	defer testassets.Catalog()()
+	c := &pb.Character{}
+	p := &pb.Profile{BankInventoryList: [][]byte{{0x03}}}
+	for i := 0; i < BackpackSize(c); i++ {
+		index, err := AddItem(c, []byte{0x04}, ItemFlags{Favorite: i == 0})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.InventoryItems[index].PickupOrderIndex != int32(i) {
+			t.Fatalf("invalid pickup order index %d", c.InventoryItems[index].PickupOrderIndex)
+		}
+	}
+	if !GetItemFlags(c.InventoryItems[0]).Favorite || GetItemFlags(c.InventoryItems[1]).Favorite {
+		t.Fatal("invalid item flags")
+	}
+	if _, err := MoveBankItemToCharacter(p, c, 0); err != ErrBackpackFull || len(p.BankInventoryList) != 1 {
+		t.Fatal("expected full backpack")
+	}
+	if err := MoveItemToBank(c, p, 0); err != nil {
+		t.Fatal(err)
+	}
+	index, err := MoveBankItemToCharacter(p, c, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.InventoryItems[index].ItemSerialNumber[0] != 0x03 || len(p.BankInventoryList) != 1 {
+		t.Fatal("bank item not moved")
+	}
+}
+
+func TestSetSDU(t *testing.T) {
+	defer testassets.Catalog()()
+	c := &pb.Character{}
+	if err := SetSDU(c, "backpack", 2); err != nil {
+		t.Fatal(err)
+	}
+	if BackpackSize(c) != 21 {
+		t.Fatalf("unexpected backpack size %d", BackpackSize(c))
+	}
+	for _, name := range []string{"Bank", "Backpack 2"} {
+		if err := SetSDU(c, name, 1); err == nil {
+			t.Errorf("expected error setting SDU %s", name)
+		}
+	}
+	if err := SetSDU(c, "Backpack", 9); err == nil {
+		t.Error("expected error for level above the maximum")
+	}
+	if err := MaxSDUs(c); err != nil {
+		t.Fatal(err)
+	}
+	if BackpackSize(c) != 39 || len(c.SduList) != 8 {
+		t.Fatalf("SDUs not maxed: %v", c.SduList)
+	}
+}
+
+func TestUnknownSDUs(t *testing.T) {
+	c := &pb.Character{}
+	if BackpackSize(c) != -1 {
+		t.Fatalf("expected unknown backpack size, got %d", BackpackSize(c))
+	}
+	if _, err := AddItem(c, []byte{0x04}, ItemFlags{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetSDU(c, "Backpack", 50); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetSDU(c, "Backpack", -1); err == nil {
+		t.Error("expected error for negative level")
+	}
+	if err := MaxSDUs(c); err == nil || len(c.SduList) != 1 {
+		t.Fatalf("expected error without changes for unknown maximum levels: %v", c.SduList)
+	}
+}
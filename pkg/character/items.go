@@ -0,0 +1,50 @@
+package character
+
+import (
+	"fmt"
+
+	"github.com/cfi2017/bl3-save-core/pkg/item"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+/*
+GetItems decodes the inventory items of the character.
+Each item keeps its inventory entry as wrapper. Returns an error if an item can't be decoded,
+items of unknown categories are kept as they are.
+This requires a valid database to be set.
+*/
+func GetItems(c *pb.Character) ([]item.Item, error) {
+	items := make([]item.Item, 0, len(c.InventoryItems))
+	for index, data := range c.InventoryItems {
+		i, err := item.Decode(data.ItemSerialNumber)
+		// items of unknown categories are written back unchanged, see item.Serialize
+		if err != nil && !i.SkipIntrospection {
+			return nil, fmt.Errorf("inventory item %d: %v", index, err)
+		}
+		i.Wrapper = data
+		items = append(items, i)
+	}
+	return items, nil
+}
+
+/*
+SetItems serializes the items back into the character's inventory, using their wrappers as inventory entries.
+Items without a balance are written back unchanged.
+This requires a valid database to be set.
+*/
+func SetItems(c *pb.Character, items []item.Item) error {
+	list := make([]*pb.OakInventoryItemSaveGameData, len(items))
+	for index, i := range items {
+		serial, err := item.Reserialize(i)
+		if err != nil {
+			return err
+		}
+		list[index] = i.Wrapper
+		if list[index] == nil {
+			list[index] = &pb.OakInventoryItemSaveGameData{}
+		}
+		list[index].ItemSerialNumber = serial
+	}
+	c.InventoryItems = list
+	return nil
+}
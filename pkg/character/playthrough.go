@@ -0,0 +1,112 @@
+package character
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+const (
+	// MaxPlaythroughs is the number of playthroughs (normal and true vault hunter mode) the game supports.
+	MaxPlaythroughs = 2
+	// MaxMayhemLevel is the highest mayhem level the game accepts.
+	MaxMayhemLevel = 10
+)
+
+/*
+Playthroughs returns the number of playthroughs that are available to the character.
+*/
+func Playthroughs(c *pb.Character) int {
+	n := int(c.PlaythroughsCompleted) + 1
+	if n > MaxPlaythroughs {
+		return MaxPlaythroughs
+	}
+	return n
+}
+
+/*
+UnlockPlaythrough makes the zero-based playthrough n (0 = NVHM, 1 = TVHM) available to the character.
+All per-playthrough slices are padded so that every playthrough up to n has an entry.
+*/
+func UnlockPlaythrough(c *pb.Character, n int) error {
+	if n < 0 || n >= MaxPlaythroughs {
+		return fmt.Errorf("invalid playthrough %d, expected 0 to %d", n, MaxPlaythroughs-1)
+	}
+	if int(c.PlaythroughsCompleted) < n {
+		c.PlaythroughsCompleted = int32(n)
+		c.ShowNewPlaythroughNotification = true
+	}
+	ensurePlaythroughs(c, n+1)
+	if c.LastPlayThroughIndex < 0 || int(c.LastPlayThroughIndex) >= len(c.MissionPlaythroughsData) {
+		c.LastPlayThroughIndex = 0
+	}
+	return nil
+}
+
+/*
+SetMayhem sets the mayhem level for every available playthrough.
+A mayhem random seed is rolled for playthroughs that don't have one yet.
+*/
+func SetMayhem(c *pb.Character, level int) error {
+	for pt := 0; pt < Playthroughs(c); pt++ {
+		if err := SetMayhemForPlaythrough(c, pt, level); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+SetMayhemForPlaythrough sets the mayhem level for the zero-based playthrough pt.
+The character wide mayhem level follows the playthrough the character was last played in.
+*/
+func SetMayhemForPlaythrough(c *pb.Character, pt int, level int) error {
+	if level < 0 || level > MaxMayhemLevel {
+		return fmt.Errorf("invalid mayhem level %d, expected 0 to %d", level, MaxMayhemLevel)
+	}
+	if pt < 0 || pt >= Playthroughs(c) {
+		return fmt.Errorf("playthrough %d is not unlocked", pt)
+	}
+	ensurePlaythroughs(c, Playthroughs(c))
+	state := c.GameStateSaveDataForPlaythrough[pt]
+	state.MayhemLevel = int32(level)
+	if level > 0 && state.MayhemRandomSeed == 0 {
+		state.MayhemRandomSeed = rand.Int31()
+	}
+	if int(c.LastPlayThroughIndex) == pt {
+		c.MayhemLevel = uint32(level)
+	}
+	return nil
+}
+
+/*
+ensurePlaythroughs pads all per-playthrough slices to hold at least n entries.
+New entries are empty, except for the game state which inherits the last traveled map.
+*/
+func ensurePlaythroughs(c *pb.Character, n int) {
+	for len(c.MissionPlaythroughsData) < n {
+		c.MissionPlaythroughsData = append(c.MissionPlaythroughsData, &pb.MissionPlaythroughSaveGameData{})
+	}
+	for len(c.GameStateSaveDataForPlaythrough) < n {
+		state := &pb.GameStateSaveData{}
+		if c.LastTraveledMapId != nil {
+			state.LastTraveledMapId = &pb.MapIDData{
+				ZoneNameId: c.LastTraveledMapId.ZoneNameId,
+				MapNameId:  c.LastTraveledMapId.MapNameId,
+			}
+		}
+		c.GameStateSaveDataForPlaythrough = append(c.GameStateSaveDataForPlaythrough, state)
+	}
+	for i, state := range c.GameStateSaveDataForPlaythrough {
+		if state == nil {
+			c.GameStateSaveDataForPlaythrough[i] = &pb.GameStateSaveData{}
+		}
+	}
+	for len(c.ActiveTravelStationsForPlaythrough) < n {
+		c.ActiveTravelStationsForPlaythrough = append(c.ActiveTravelStationsForPlaythrough, &pb.PlaythroughActiveFastTravelSaveData{})
+	}
+	for len(c.LastActiveTravelStationForPlaythrough) < n {
+		c.LastActiveTravelStationForPlaythrough = append(c.LastActiveTravelStationForPlaythrough, "")
+	}
+}
@@ -0,0 +1,49 @@
+package character
+
+import (
+	"testing"
+
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+func TestUnlockPlaythrough(t *testing.T) {
+	c := &pb.Character{
+		MissionPlaythroughsData:               []*pb.MissionPlaythroughSaveGameData{{}},
+		GameStateSaveDataForPlaythrough:       []*pb.GameStateSaveData{{MayhemLevel: 3}},
+		LastActiveTravelStationForPlaythrough: []string{"station"},
+	}
+	if err := UnlockPlaythrough(c, 1); err != nil {
+		t.Fatal(err)
+	}
+	if c.PlaythroughsCompleted != 1 || Playthroughs(c) != 2 {
+		t.Fatalf("invalid playthroughs completed %d", c.PlaythroughsCompleted)
+	}
+	if len(c.MissionPlaythroughsData) != 2 || len(c.GameStateSaveDataForPlaythrough) != 2 ||
+		len(c.ActiveTravelStationsForPlaythrough) != 2 || len(c.LastActiveTravelStationForPlaythrough) != 2 {
+		t.Fatal("per-playthrough data not padded")
+	}
+	if c.GameStateSaveDataForPlaythrough[0].MayhemLevel != 3 || c.LastActiveTravelStationForPlaythrough[0] != "station" {
+		t.Fatal("existing playthrough data was modified")
+	}
+	if err := UnlockPlaythrough(c, 2); err == nil {
+		t.Fatal("expected error for invalid playthrough")
+	}
+}
+
+func TestSetMayhem(t *testing.T) {
+	c := &pb.Character{PlaythroughsCompleted: 1}
+	if err := SetMayhem(c, 10); err != nil {
+		t.Fatal(err)
+	}
+	for pt, state := range c.GameStateSaveDataForPlaythrough {
+		if state.MayhemLevel != 10 {
+			t.Fatalf("mayhem level not set for playthrough %d", pt)
+		}
+	}
+	if c.MayhemLevel != 10 {
+		t.Fatal("character mayhem level not set")
+	}
+	if err := SetMayhem(c, MaxMayhemLevel+1); err == nil {
+		t.Fatal("expected error for invalid mayhem level")
+	}
+}
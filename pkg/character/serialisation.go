@@ -1,6 +1,7 @@
 package character
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/cfi2017/bl3-save-core/pkg/pb"
@@ -56,11 +57,34 @@ func Deserialize(reader io.Reader, platform string) (shared.SavFile, pb.Characte
 }
 
 func Serialize(writer io.Writer, s shared.SavFile, p pb.Character, platform string) {
-	bs, err := proto.Marshal(&p)
-	if err != nil {
+	if err := Encode(writer, s, &p, platform); err != nil {
 		panic(err)
 	}
+}
+
+/*
+Encode serializes a character like Serialize, but returns errors instead of panicking.
+*/
+func Encode(writer io.Writer, s shared.SavFile, p *pb.Character, platform string) error {
+	if _, ok := platforms[platform]; !ok {
+		return fmt.Errorf("unknown platform %s", platform)
+	}
+	bs, err := proto.Marshal(p)
+	if err != nil {
+		return err
+	}
 	bs = shared.Encrypt(bs, platforms[platform].Prefix, platforms[platform].Xor)
 	shared.SerializeHeader(writer, s, bs)
+	return nil
+}
 
+/*
+Verify decodes a character save and encodes it again without changes, reporting whether the result matches the original.
+*/
+func Verify(data []byte, platform string) (shared.VerifyResult, error) {
+	magic, ok := platforms[platform]
+	if !ok {
+		return shared.VerifyResult{}, fmt.Errorf("unknown platform %s", platform)
+	}
+	return shared.Verify(data, magic, &pb.Character{})
 }
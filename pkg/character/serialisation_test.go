@@ -0,0 +1,56 @@
+package character
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+	"github.com/cfi2017/bl3-save-core/pkg/shared"
+	"google.golang.org/protobuf/proto"
+)
+
+func testHeader() shared.SavFile {
+	return shared.SavFile{
+		SgVersion:     2,
+		PkgVersion:    516,
+		BuildId:       "OAK-PATCHDIESEL1-280",
+		FmtVersion:    3,
+		FmtCount:      1,
+		CustomFmtData: []shared.CustomFormatData{{Guid: "0123456789abcdef0123456789abcdef", Entry: 1}},
+		SgType:        "OakSaveGame",
+	}
+}
+
+func TestVerify(t *testing.T) {
+	c := &pb.Character{SaveGameId: 2, PreferredCharacterName: "Amara", ExperiencePoints: 1000}
+	buf := new(bytes.Buffer)
+	if err := Encode(buf, testHeader(), c, "pc"); err != nil {
+		t.Fatal(err)
+	}
+	result, err := Verify(buf.Bytes(), "pc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Status != shared.Identical || result.Offset != -1 {
+		t.Fatalf("expected identical save, got %+v", result)
+	}
+
+	// the game doesn't have to write fields in order, reordering them keeps the save equivalent
+	name, _ := proto.Marshal(&pb.Character{PreferredCharacterName: "Amara"})
+	rest, _ := proto.Marshal(&pb.Character{SaveGameId: 2, ExperiencePoints: 1000})
+	data := append(name, rest...)
+	buf.Reset()
+	shared.SerializeHeader(buf, testHeader(), shared.Encrypt(data, platforms["pc"].Prefix, platforms["pc"].Xor))
+	result, err = Verify(buf.Bytes(), "pc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Status != shared.Equivalent || result.Offset < 0 || len(result.Fields) == 0 {
+		t.Fatalf("expected equivalent save, got %+v", result)
+	}
+	for _, f := range result.Fields {
+		if !f.Equal {
+			t.Errorf("field %s reported as changed", f.Name)
+		}
+	}
+}
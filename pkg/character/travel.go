@@ -0,0 +1,215 @@
+package character
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/cfi2017/bl3-save-core/pkg/assets"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+/*
+GetTravelStations returns the paths of all fast travel stations registered in assets.TravelStations
+or known to the character, i.e. activated or blacklisted in any playthrough.
+*/
+func GetTravelStations(c *pb.Character) []string {
+	return travelStations(c, func(assets.TravelStation) bool { return true })
+}
+
+// travelStations returns the paths of the registered stations matching the filter and all stations known to the character.
+func travelStations(c *pb.Character, filter func(assets.TravelStation) bool) []string {
+	stations := make([]string, 0)
+	add := func(station string) {
+		if station != "" && !containsString(stations, station) {
+			stations = append(stations, station)
+		}
+	}
+	for _, s := range assets.TravelStations {
+		if filter(s) {
+			add(s.Path)
+		}
+	}
+	for _, s := range c.ActiveOrBlacklistedTravelStations {
+		add(s.ActiveTravelStationName)
+	}
+	for _, data := range c.ActiveTravelStationsForPlaythrough {
+		for _, s := range data.ActiveTravelStations {
+			add(s.ActiveTravelStationName)
+		}
+	}
+	for _, s := range c.ActiveTravelStations {
+		add(s)
+	}
+	add(c.LastActiveTravelStation)
+	for _, s := range c.LastActiveTravelStationForPlaythrough {
+		add(s)
+	}
+	return stations
+}
+
+/*
+UnlockTravelStations activates fast travel stations for the zero-based playthrough pt.
+Names may be stations as known by assets.FindTravelStation or level names as known by assets.FindLevel,
+in which case the registered stations of that level available in the playthrough are unlocked.
+If no names are given, all registered stations available in the playthrough and all stations known to the character
+are unlocked, see GetTravelStations.
+*/
+func UnlockTravelStations(c *pb.Character, pt int, names ...string) error {
+	if pt < 0 || pt >= Playthroughs(c) {
+		return fmt.Errorf("playthrough %d is not unlocked", pt)
+	}
+	stations := travelStations(c, func(s assets.TravelStation) bool { return s.InPlaythrough(pt) })
+	if len(names) > 0 {
+		stations = make([]string, 0, len(names))
+		for _, name := range names {
+			if l, ok := assets.FindLevel(name); ok {
+				levelStations := assets.GetLevelTravelStations(l.Path, pt)
+				if len(levelStations) == 0 {
+					return fmt.Errorf("no travel stations registered for level %s in playthrough %d", l.Name, pt)
+				}
+				for _, s := range levelStations {
+					stations = append(stations, s.Path)
+				}
+			} else if s, ok := assets.FindTravelStation(name); ok {
+				stations = append(stations, s.Path)
+			} else {
+				return fmt.Errorf("unknown travel station or level: %s", name)
+			}
+		}
+	}
+	ensurePlaythroughs(c, Playthroughs(c))
+	data := c.ActiveTravelStationsForPlaythrough[pt]
+	for _, station := range stations {
+		data.ActiveTravelStations = activateStation(data.ActiveTravelStations, station)
+		c.ActiveOrBlacklistedTravelStations = activateStation(c.ActiveOrBlacklistedTravelStations, station)
+		if !containsString(c.ActiveTravelStations, station) {
+			c.ActiveTravelStations = append(c.ActiveTravelStations, station)
+		}
+	}
+	return nil
+}
+
+/*
+RevealLevels marks the given levels and all of their known areas as discovered for the zero-based playthrough pt
+and clears the fog of war for levels the character has already visited.
+Levels are resolved through assets.FindLevel. If no levels are given, all levels in the catalog
+and all levels the character has discovered in any playthrough are revealed.
+*/
+func RevealLevels(c *pb.Character, pt int, levels ...string) error {
+	if pt < 0 || pt >= Playthroughs(c) {
+		return fmt.Errorf("playthrough %d is not unlocked", pt)
+	}
+	paths := make([]string, 0, len(levels))
+	for _, name := range levels {
+		l, ok := assets.FindLevel(name)
+		if !ok {
+			return fmt.Errorf("unknown level: %s", name)
+		}
+		paths = append(paths, l.Path)
+	}
+	if len(levels) == 0 {
+		for _, l := range assets.Levels {
+			paths = append(paths, l.Path)
+		}
+		if c.DiscoveryData != nil {
+			for _, info := range c.DiscoveryData.DiscoveredLevelInfo {
+				if !containsString(paths, info.DiscoveredLevelName) {
+					paths = append(paths, info.DiscoveredLevelName)
+				}
+			}
+		}
+		if c.GbxZoneMapFodSaveGameData != nil {
+			for _, d := range c.GbxZoneMapFodSaveGameData.LevelData {
+				clearFog(d)
+			}
+		}
+	}
+	for _, path := range paths {
+		revealLevel(c, pt, path)
+	}
+	return nil
+}
+
+/*
+RevealPlanets reveals all levels in the catalog on the given planets for the zero-based playthrough pt.
+If no planets are given, all levels are revealed as by RevealLevels.
+*/
+func RevealPlanets(c *pb.Character, pt int, planets ...string) error {
+	if len(planets) == 0 {
+		return RevealLevels(c, pt)
+	}
+	levels := make([]string, 0)
+	for _, name := range planets {
+		p, ok := assets.FindPlanet(name)
+		if !ok {
+			return fmt.Errorf("unknown planet: %s", name)
+		}
+		for _, l := range assets.GetPlanetLevels(p.Name) {
+			levels = append(levels, l.Path)
+		}
+	}
+	if len(levels) == 0 {
+		return nil
+	}
+	return RevealLevels(c, pt, levels...)
+}
+
+func revealLevel(c *pb.Character, pt int, path string) {
+	mask := uint32(1) << uint(pt)
+	if c.DiscoveryData == nil {
+		c.DiscoveryData = &pb.DiscoverySaveData{}
+	}
+	var info *pb.DiscoveredLevelInfo
+	for _, l := range c.DiscoveryData.DiscoveredLevelInfo {
+		if l.DiscoveredLevelName == path {
+			info = l
+			break
+		}
+	}
+	if info == nil {
+		info = &pb.DiscoveredLevelInfo{DiscoveredLevelName: path}
+		c.DiscoveryData.DiscoveredLevelInfo = append(c.DiscoveryData.DiscoveredLevelInfo, info)
+	}
+	info.DiscoveredPlaythroughs |= mask
+	for _, area := range info.DiscoveredAreaInfo {
+		area.DiscoveredPlaythroughs |= mask
+	}
+
+	// fog of war data is only created by the game once a level has been visited
+	if c.GbxZoneMapFodSaveGameData == nil {
+		return
+	}
+	name := path[strings.LastIndex(path, "/")+1:]
+	for _, d := range c.GbxZoneMapFodSaveGameData.LevelData {
+		if d.LevelName == name || d.LevelName == path {
+			clearFog(d)
+		}
+	}
+}
+
+func clearFog(d *pb.GbxZoneMapFODSavedLevelData) {
+	for i := range d.FodData {
+		d.FodData[i] = math.MaxUint8
+	}
+	d.DiscoveryPercentage = 100
+}
+
+func activateStation(list []*pb.ActiveFastTravelSaveData, station string) []*pb.ActiveFastTravelSaveData {
+	for _, s := range list {
+		if s.ActiveTravelStationName == station {
+			s.Blacklisted = false
+			return list
+		}
+	}
+	return append(list, &pb.ActiveFastTravelSaveData{ActiveTravelStationName: station})
+}
+
+func containsString(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package character
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cfi2017/bl3-save-core/internal/testassets"
+	"github.com/cfi2017/bl3-save-core/pkg/assets"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+const (
+	testStation      = "/Game/GameData/FastTravel/Test_Station.Test_Station"
+	testOtherStation = "/Game/GameData/FastTravel/Test_OtherStation.Test_OtherStation"
+)
+
+func activeStations(list []*pb.ActiveFastTravelSaveData) map[string]bool {
+	stations := make(map[string]bool)
+	for _, s := range list {
+		stations[s.ActiveTravelStationName] = !s.Blacklisted
+	}
+	return stations
+}
+
+func TestUnlockTravelStations(t *testing.T) {
+	c := &pb.Character{
+		PlaythroughsCompleted: 1,
+		ActiveTravelStationsForPlaythrough: []*pb.PlaythroughActiveFastTravelSaveData{
+			{ActiveTravelStations: []*pb.ActiveFastTravelSaveData{{ActiveTravelStationName: testStation}}},
+		},
+		ActiveOrBlacklistedTravelStations: []*pb.ActiveFastTravelSaveData{
+			{ActiveTravelStationName: testStation},
+			{ActiveTravelStationName: testOtherStation, Blacklisted: true},
+		},
+	}
+	if err := UnlockTravelStations(c, 2); err == nil {
+		t.Fatal("expected error for locked playthrough")
+	}
+	if err := UnlockTravelStations(c, 1); err != nil {
+		t.Fatal(err)
+	}
+	// stations known from the first playthrough are unlocked in the second one
+	stations := activeStations(c.ActiveTravelStationsForPlaythrough[1].ActiveTravelStations)
+	if len(stations) != 2 || !stations[testStation] || !stations[testOtherStation] {
+		t.Fatalf("invalid stations for second playthrough: %v", stations)
+	}
+	if !activeStations(c.ActiveOrBlacklistedTravelStations)[testOtherStation] {
+		t.Fatal("blacklisted station wasn't activated")
+	}
+	if len(c.ActiveTravelStations) != 2 {
+		t.Fatalf("invalid active stations %v", c.ActiveTravelStations)
+	}
+	if len(c.ActiveTravelStationsForPlaythrough[0].ActiveTravelStations) != 1 {
+		t.Fatal("first playthrough was modified")
+	}
+}
+
+func TestUnlockTravelStationsByName(t *testing.T) {
+	level := assets.Levels[0]
+	defer func(stations []assets.TravelStation) { assets.TravelStations = stations }(assets.TravelStations)
+	c := &pb.Character{}
+	if err := UnlockTravelStations(c, 0, level.Name); err == nil {
+		t.Fatal("expected error for level without registered stations")
+	}
+	if err := UnlockTravelStations(c, 0, "unknown"); err == nil {
+		t.Fatal("expected error for unknown station")
+	}
+	assets.TravelStations = append(assets.TravelStations, assets.TravelStation{Name: "Test", Level: level.Path, Path: testStation})
+	if err := UnlockTravelStations(c, 0, level.Name, testOtherStation); err != nil {
+		t.Fatal(err)
+	}
+	stations := activeStations(c.ActiveTravelStationsForPlaythrough[0].ActiveTravelStations)
+	if len(stations) != 2 || !stations[testStation] || !stations[testOtherStation] {
+		t.Fatalf("invalid stations %v", stations)
+	}
+}
+
+func TestRevealLevels(t *testing.T) {
+	visited := assets.Levels[0]
+	dlc := "/Game/PatchDLC/Test/Maps/Test_P"
+	c := &pb.Character{
+		PlaythroughsCompleted: 1,
+		DiscoveryData: &pb.DiscoverySaveData{DiscoveredLevelInfo: []*pb.DiscoveredLevelInfo{
+			{DiscoveredLevelName: visited.Path, DiscoveredPlaythroughs: 1, DiscoveredAreaInfo: []*pb.DiscoveredAreaInfo{
+				{DiscoveredAreaName: "area", DiscoveredPlaythroughs: 1},
+			}},
+			{DiscoveredLevelName: dlc, DiscoveredPlaythroughs: 1},
+		}},
+		GbxZoneMapFodSaveGameData: &pb.GbxZoneMapFODSaveGameData{LevelData: []*pb.GbxZoneMapFODSavedLevelData{
+			{LevelName: "Prologue_P", FodData: []byte{0, 1}},
+			{LevelName: "Test_P", FodData: []byte{0}},
+		}},
+	}
+	if err := RevealLevels(c, 0, "unknown"); err == nil {
+		t.Fatal("expected error for unknown level")
+	}
+	if err := RevealLevels(c, 1, visited.Name); err != nil {
+		t.Fatal(err)
+	}
+	info := c.DiscoveryData.DiscoveredLevelInfo[0]
+	if info.DiscoveredPlaythroughs != 3 || info.DiscoveredAreaInfo[0].DiscoveredPlaythroughs != 3 {
+		t.Fatal("level wasn't discovered in second playthrough")
+	}
+	fod := c.GbxZoneMapFodSaveGameData.LevelData
+	if fod[0].DiscoveryPercentage != 100 || fod[0].FodData[0] != math.MaxUint8 || fod[0].FodData[1] != math.MaxUint8 {
+		t.Fatal("fog of war wasn't cleared")
+	}
+	if c.DiscoveryData.DiscoveredLevelInfo[1].DiscoveredPlaythroughs != 1 || fod[1].DiscoveryPercentage != 0 {
+		t.Fatal("unrelated level was revealed")
+	}
+
+	if err := RevealLevels(c, 1); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.DiscoveryData.DiscoveredLevelInfo) != len(assets.Levels)+1 {
+		t.Fatalf("invalid number of discovered levels %d", len(c.DiscoveryData.DiscoveredLevelInfo))
+	}
+	for _, info := range c.DiscoveryData.DiscoveredLevelInfo {
+		if info.DiscoveredPlaythroughs&2 == 0 {
+			t.Fatalf("level %s wasn't revealed", info.DiscoveredLevelName)
+		}
+	}
+	if fod[1].DiscoveryPercentage != 100 {
+		t.Fatal("fog of war of visited level outside the catalog wasn't cleared")
+	}
+}
+
+func TestRevealPlanets(t *testing.T) {
+	c := &pb.Character{}
+	if err := RevealPlanets(c, 0, "unknown"); err == nil {
+		t.Fatal("expected error for unknown planet")
+	}
+	if err := RevealPlanets(c, 0, "Eden-6"); err != nil {
+		t.Fatal(err)
+	}
+	levels := assets.GetPlanetLevels("Eden-6")
+	if len(levels) == 0 || len(c.DiscoveryData.DiscoveredLevelInfo) != len(levels) {
+		t.Fatalf("invalid number of discovered levels %d", len(c.DiscoveryData.DiscoveredLevelInfo))
+	}
+	for i, l := range levels {
+		info := c.DiscoveryData.DiscoveredLevelInfo[i]
+		if info.DiscoveredLevelName != l.Path || info.DiscoveredPlaythroughs != 1 {
+			t.Fatalf("level %s wasn't revealed", l.Name)
+		}
+	}
+}
+
+func TestUnlockTravelStationsFromCatalog(t *testing.T) {
+	defer testassets.Catalog()()
+	c := &pb.Character{
+		PlaythroughsCompleted: 1,
+		ActiveTravelStationsForPlaythrough: []*pb.PlaythroughActiveFastTravelSaveData{
+			{ActiveTravelStations: []*pb.ActiveFastTravelSaveData{{ActiveTravelStationName: testStation}}},
+		},
+	}
+	if err := UnlockTravelStations(c, 0); err != nil {
+		t.Fatal(err)
+	}
+	// stations missing from the save are added, stations of other playthroughs aren't
+	stations := activeStations(c.ActiveTravelStationsForPlaythrough[0].ActiveTravelStations)
+	if len(stations) != 2 || !stations[testStation] || !stations[testassets.Station] {
+		t.Fatalf("invalid stations for first playthrough: %v", stations)
+	}
+	if err := UnlockTravelStations(c, 1, assets.Levels[0].Name); err != nil {
+		t.Fatal(err)
+	}
+	stations = activeStations(c.ActiveTravelStationsForPlaythrough[1].ActiveTravelStations)
+	if len(stations) != 2 || !stations[testassets.Station] || !stations[testassets.SecondPlaythroughStation] {
+		t.Fatalf("invalid stations for second playthrough: %v", stations)
+	}
+}
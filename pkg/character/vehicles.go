@@ -0,0 +1,182 @@
+package character
+
+import (
+	"fmt"
+
+	"github.com/cfi2017/bl3-save-core/pkg/assets"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+// Loadout is a vehicle loadout as configured at a catch-a-ride station.
+// Parts are referenced by their friendly name or asset path, empty parts are left unequipped.
+type Loadout struct {
+	Name          string   `json:"name"`
+	Body          string   `json:"body"`
+	Wheel         string   `json:"wheel"`
+	Armor         string   `json:"armor"`
+	CoreMod       string   `json:"coreMod"`
+	GunnerWeapon  string   `json:"gunnerWeapon"`
+	DriverWeapon  string   `json:"driverWeapon"`
+	Ornament      string   `json:"ornament"`
+	MaterialDecal string   `json:"materialDecal"`
+	Material      string   `json:"material"`
+	Colors        [3]int32 `json:"colors"`
+}
+
+/*
+UnlockAllVehicles unlocks every vehicle chassis, part and skin registered in assets.Vehicles.
+Returns an error if no vehicles are registered.
+*/
+func UnlockAllVehicles(c *pb.Character) error {
+	if len(assets.Vehicles) == 0 {
+		return fmt.Errorf("no vehicles known, they're read from the catalog")
+	}
+	for _, v := range assets.Vehicles {
+		if !isVehicleUnlocked(c, v.Chassis) {
+			c.VehiclesUnlockedData = append(c.VehiclesUnlockedData, &pb.VehicleUnlockedSaveGameData{
+				AssetPath:    v.Chassis,
+				JustUnlocked: true,
+			})
+		}
+		for _, p := range v.Parts {
+			if !containsString(c.VehiclePartsUnlocked, p.Path) {
+				c.VehiclePartsUnlocked = append(c.VehiclePartsUnlocked, p.Path)
+			}
+		}
+	}
+	return nil
+}
+
+/*
+GetLoadouts returns all vehicle loadouts of the character. Known parts are returned by their friendly name.
+*/
+func GetLoadouts(c *pb.Character) []Loadout {
+	loadouts := make([]Loadout, len(c.VehicleLoadouts))
+	for i, l := range c.VehicleLoadouts {
+		loadouts[i] = Loadout{
+			Name:          l.LoadoutSaveName,
+			Body:          vehiclePartName(l.BodyAssetPath),
+			Wheel:         vehiclePartName(l.WheelAssetPath),
+			Armor:         vehiclePartName(l.ArmorAssetPath),
+			CoreMod:       vehiclePartName(l.CoreModAssetPath),
+			GunnerWeapon:  vehiclePartName(l.GunnerWeaponAssetPath),
+			DriverWeapon:  vehiclePartName(l.DriverWeaponAssetPath),
+			Ornament:      vehiclePartName(l.OrnamentAssetPath),
+			MaterialDecal: vehiclePartName(l.MaterialDecalAssetPath),
+			Material:      vehiclePartName(l.MaterialAssetPath),
+			Colors:        [3]int32{l.ColorIndex_1, l.ColorIndex_2, l.ColorIndex_3},
+		}
+	}
+	return loadouts
+}
+
+/*
+SetLoadout creates or replaces the loadout with the same name and makes it the last used loadout.
+Returns an error if the loadout references parts that are unknown, don't fit the slot or are not unlocked.
+*/
+func SetLoadout(c *pb.Character, l Loadout) error {
+	if l.Name == "" {
+		return fmt.Errorf("loadout name must not be empty")
+	}
+	data := &pb.OakCARMenuVehicleConfigSaveData{
+		LoadoutSaveName: l.Name,
+		ColorIndex_1:    l.Colors[0],
+		ColorIndex_2:    l.Colors[1],
+		ColorIndex_3:    l.Colors[2],
+	}
+	slots := []struct {
+		name string
+		kind assets.VehiclePartKind
+		dst  *string
+	}{
+		{l.Body, assets.VehicleBody, &data.BodyAssetPath},
+		{l.Wheel, assets.VehicleWheel, &data.WheelAssetPath},
+		{l.Armor, assets.VehicleArmor, &data.ArmorAssetPath},
+		{l.CoreMod, assets.VehicleCoreMod, &data.CoreModAssetPath},
+		{l.GunnerWeapon, assets.VehicleGunnerWeapon, &data.GunnerWeaponAssetPath},
+		{l.DriverWeapon, assets.VehicleDriverWeapon, &data.DriverWeaponAssetPath},
+		{l.Ornament, assets.VehicleOrnament, &data.OrnamentAssetPath},
+		{l.MaterialDecal, assets.VehicleMaterialDecal, &data.MaterialDecalAssetPath},
+		{l.Material, assets.VehicleMaterial, &data.MaterialAssetPath},
+	}
+	for _, slot := range slots {
+		if slot.name == "" {
+			continue
+		}
+		p, ok := assets.FindVehiclePart(slot.name)
+		if !ok {
+			return fmt.Errorf("unknown vehicle part: %s", slot.name)
+		}
+		if p.Kind != slot.kind {
+			return fmt.Errorf("vehicle part %s is a %s, not a %s", p.Name, p.Kind, slot.kind)
+		}
+		if !containsString(c.VehiclePartsUnlocked, p.Path) {
+			return fmt.Errorf("vehicle part %s is not unlocked", p.Name)
+		}
+		*slot.dst = p.Path
+	}
+
+	for i, existing := range c.VehicleLoadouts {
+		if existing.LoadoutSaveName == l.Name {
+			c.VehicleLoadouts[i] = data
+			c.VehicleLastLoadoutIndex = int32(i)
+			return nil
+		}
+	}
+	c.VehicleLoadouts = append(c.VehicleLoadouts, data)
+	c.VehicleLastLoadoutIndex = int32(len(c.VehicleLoadouts) - 1)
+	return nil
+}
+
+/*
+RemoveLoadout removes the loadout with the given name and keeps the last used loadout index pointing at the same loadout.
+*/
+func RemoveLoadout(c *pb.Character, name string) error {
+	for i, l := range c.VehicleLoadouts {
+		if l.LoadoutSaveName != name {
+			continue
+		}
+		c.VehicleLoadouts = append(c.VehicleLoadouts[:i], c.VehicleLoadouts[i+1:]...)
+		if int(c.VehicleLastLoadoutIndex) > i || int(c.VehicleLastLoadoutIndex) >= len(c.VehicleLoadouts) {
+			c.VehicleLastLoadoutIndex--
+		}
+		if c.VehicleLastLoadoutIndex < 0 {
+			c.VehicleLastLoadoutIndex = 0
+		}
+		return nil
+	}
+	return fmt.Errorf("no loadout named %s", name)
+}
+
+/*
+ValidateLoadouts checks that all loadouts of the character only reference unlocked parts.
+*/
+func ValidateLoadouts(c *pb.Character) error {
+	for _, l := range c.VehicleLoadouts {
+		for _, path := range []string{
+			l.BodyAssetPath, l.WheelAssetPath, l.ArmorAssetPath, l.CoreModAssetPath, l.GunnerWeaponAssetPath,
+			l.DriverWeaponAssetPath, l.OrnamentAssetPath, l.MaterialDecalAssetPath, l.MaterialAssetPath,
+		} {
+			if path != "" && !containsString(c.VehiclePartsUnlocked, path) {
+				return fmt.Errorf("loadout %s references locked part %s", l.LoadoutSaveName, path)
+			}
+		}
+	}
+	return nil
+}
+
+func isVehicleUnlocked(c *pb.Character, chassis string) bool {
+	for _, v := range c.VehiclesUnlockedData {
+		if v.AssetPath == chassis {
+			return true
+		}
+	}
+	return false
+}
+
+func vehiclePartName(path string) string {
+	if p, ok := assets.FindVehiclePart(path); ok {
+		return p.Name
+	}
+	return path
+}
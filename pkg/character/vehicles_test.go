@@ -0,0 +1,42 @@
+package character
+
+import (
+	"testing"
+
+	"github.com/cfi2017/bl3-save-core/internal/testassets"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+func TestSetLoadout(t *testing.T) {
+	c := &pb.Character{}
+	if err := UnlockAllVehicles(c); err == nil {
+		t.Fatal("expected error without known vehicles")
+	}
+	defer testassets.Catalog()()
+	l := Loadout{Name: "fast", Body: "Test Car Body", Wheel: "Test Car Hover Wheel"}
+	if err := SetLoadout(c, l); err == nil {
+		t.Fatal("expected error for locked parts")
+	}
+	if err := UnlockAllVehicles(c); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.VehiclesUnlockedData) != 1 || len(c.VehiclePartsUnlocked) != 3 {
+		t.Fatalf("vehicle not unlocked: %v %v", c.VehiclesUnlockedData, c.VehiclePartsUnlocked)
+	}
+	if err := SetLoadout(c, l); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetLoadout(c, Loadout{Name: "wrong", Wheel: "Test Car Body"}); err == nil {
+		t.Fatal("expected error for part in wrong slot")
+	}
+	loadouts := GetLoadouts(c)
+	if len(loadouts) != 1 || loadouts[0].Wheel != "Test Car Hover Wheel" {
+		t.Fatalf("unexpected loadouts %v", loadouts)
+	}
+	if err := ValidateLoadouts(c); err != nil {
+		t.Fatal(err)
+	}
+	if err := RemoveLoadout(c, "fast"); err != nil || len(c.VehicleLoadouts) != 0 {
+		t.Fatal("loadout not removed")
+	}
+}
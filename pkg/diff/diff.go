@@ -0,0 +1,293 @@
+// Package diff compares saves field by field.
+package diff
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/cfi2017/bl3-save-core/pkg/item"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+	"github.com/cfi2017/bl3-save-core/pkg/shared"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// none is shown for values that don't exist on one side of a change.
+const none = "(none)"
+
+// serialFields are the bytes fields holding item serials. Items are compared by their decoded content.
+var serialFields = map[protoreflect.FullName]bool{
+	"OakSave.OakInventoryItemSaveGameData.item_serial_number":         true,
+	"OakSave.CrewQuartersGunRackItemSaveData.encrypted_serial_number": true,
+	"OakSave.Profile.bank_inventory_list":                             true,
+	"OakSave.Profile.lost_loot_inventory_list":                        true,
+	"OakSave.OakProfileCloudData.bank_inventory_list":                 true,
+	"OakSave.OakProfileCloudData.lost_loot_inventory_list":            true,
+}
+
+// Change is a single difference between two saves.
+// Path uses the Go field names of the pb package, e.g. MissionPlaythroughsData[1].MissionList[12].Status.
+type Change struct {
+	Path string
+	Old  string
+	New  string
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %s -> %s", c.Path, c.Old, c.New)
+}
+
+/*
+Messages returns the differences between two messages of the same type.
+Lists are compared by index, except for lists of items which are matched by their decoded content,
+so removing an item is reported as a single change. Items are decoded if a database is set,
+otherwise they are compared by their decrypted serial, which ignores the seed.
+*/
+func Messages(a, b proto.Message) []Change {
+	d := &differ{items: map[string]item.Item{}}
+	d.message(a.ProtoReflect(), b.ProtoReflect(), "")
+	return d.changes
+}
+
+type differ struct {
+	changes []Change
+	// items caches decoded items by their serial
+	items map[string]item.Item
+}
+
+func (d *differ) add(path, old, new string) {
+	d.changes = append(d.changes, Change{Path: path, Old: old, New: new})
+}
+
+func (d *differ) message(a, b protoreflect.Message, path string) {
+	fields := a.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		p := fieldPath(path, fd)
+		switch {
+		case fd.IsMap():
+			d.mapField(fd, a.Get(fd).Map(), b.Get(fd).Map(), p)
+		case fd.IsList():
+			d.list(fd, a.Get(fd).List(), b.Get(fd).List(), p)
+		default:
+			d.value(fd, a.Get(fd), b.Get(fd), p)
+		}
+	}
+	if ua, ub := a.GetUnknown(), b.GetUnknown(); string(ua) != string(ub) {
+		d.add(fieldPath(path, nil), fmt.Sprintf("%d bytes", len(ua)), fmt.Sprintf("%d bytes", len(ub)))
+	}
+}
+
+func (d *differ) value(fd protoreflect.FieldDescriptor, a, b protoreflect.Value, path string) {
+	if fd.Message() != nil {
+		d.message(a.Message(), b.Message(), path)
+		return
+	}
+	if serialFields[fd.FullName()] {
+		ia, ib := d.item(a.Bytes()), d.item(b.Bytes())
+		if !item.Equal(ia, ib) {
+			d.add(path, describeItem(ia), describeItem(ib))
+		}
+		return
+	}
+	if !valueEqual(a, b) {
+		d.add(path, formatValue(fd, a), formatValue(fd, b))
+	}
+}
+
+func (d *differ) list(fd protoreflect.FieldDescriptor, a, b protoreflect.List, path string) {
+	if serialFields[fd.FullName()] || fd.Message() != nil && serialField(fd.Message()) != nil {
+		d.itemList(fd, a, b, path)
+		return
+	}
+	for i := 0; i < a.Len() || i < b.Len(); i++ {
+		p := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= b.Len():
+			d.removed(fd, a.Get(i), p)
+		case i >= a.Len():
+			d.added(fd, b.Get(i), p)
+		default:
+			d.value(fd, a.Get(i), b.Get(i), p)
+		}
+	}
+}
+
+// itemList matches the items of two lists by content, then compares the remaining fields of matched entries.
+func (d *differ) itemList(fd protoreflect.FieldDescriptor, a, b protoreflect.List, path string) {
+	serial := func(v protoreflect.Value) []byte {
+		if fd.Message() != nil {
+			return v.Message().Get(serialField(fd.Message())).Bytes()
+		}
+		return v.Bytes()
+	}
+	matched := make([]bool, b.Len())
+	for i := 0; i < a.Len(); i++ {
+		ia := d.item(serial(a.Get(i)))
+		match := -1
+		for j := 0; j < b.Len(); j++ {
+			if !matched[j] && item.Equal(ia, d.item(serial(b.Get(j)))) {
+				match = j
+				break
+			}
+		}
+		if match < 0 {
+			d.add(fmt.Sprintf("%s[%d]", path, i), describeItem(ia), none)
+			continue
+		}
+		matched[match] = true
+		if fd.Message() != nil {
+			d.message(a.Get(i).Message(), b.Get(match).Message(), fmt.Sprintf("%s[%d]", path, match))
+		}
+	}
+	for j := 0; j < b.Len(); j++ {
+		if !matched[j] {
+			d.add(fmt.Sprintf("%s[%d]", path, j), none, describeItem(d.item(serial(b.Get(j)))))
+		}
+	}
+}
+
+func (d *differ) mapField(fd protoreflect.FieldDescriptor, a, b protoreflect.Map, path string) {
+	keys := map[string]protoreflect.MapKey{}
+	collect := func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+		keys[k.String()] = k
+		return true
+	}
+	a.Range(collect)
+	b.Range(collect)
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+	for _, s := range sorted {
+		k := keys[s]
+		p := fmt.Sprintf("%s[%s]", path, s)
+		switch {
+		case !b.Has(k):
+			d.removed(fd.MapValue(), a.Get(k), p)
+		case !a.Has(k):
+			d.added(fd.MapValue(), b.Get(k), p)
+		default:
+			d.value(fd.MapValue(), a.Get(k), b.Get(k), p)
+		}
+	}
+}
+
+// added reports a new list or map entry. Messages are compared to an empty message, listing all set fields.
+func (d *differ) added(fd protoreflect.FieldDescriptor, v protoreflect.Value, path string) {
+	if fd.Message() != nil {
+		d.add(path, none, "{}")
+		d.message(v.Message().New(), v.Message(), path)
+		return
+	}
+	d.add(path, none, formatValue(fd, v))
+}
+
+func (d *differ) removed(fd protoreflect.FieldDescriptor, v protoreflect.Value, path string) {
+	if fd.Message() != nil {
+		d.add(path, "{}", none)
+		d.message(v.Message(), v.Message().New(), path)
+		return
+	}
+	d.add(path, formatValue(fd, v), none)
+}
+
+/*
+item decodes a serial for comparison. Serials that can't be decoded are kept decrypted, which removes the seed.
+*/
+func (d *differ) item(serial []byte) item.Item {
+	if i, ok := d.items[string(serial)]; ok {
+		return i
+	}
+	i, err := item.Decode(serial)
+	if err != nil && !i.SkipIntrospection || i.Balance == "" {
+		data := serial
+		if decrypted, err := item.DecryptSerial(append([]byte{}, serial...)); err == nil {
+			data = decrypted
+		}
+		i = item.Item{Wrapper: &pb.OakInventoryItemSaveGameData{ItemSerialNumber: data}}
+	}
+	d.items[string(serial)] = i
+	return i
+}
+
+func describeItem(i item.Item) string {
+	if i.Balance == "" {
+		return "item " + hex.EncodeToString(i.Wrapper.ItemSerialNumber)
+	}
+	balance := i.Balance[strings.LastIndexAny(i.Balance, "/.")+1:]
+	return fmt.Sprintf("%s level %d", balance, i.Level)
+}
+
+// serialField returns the item serial field of a message, or nil if it doesn't hold an item.
+func serialField(md protoreflect.MessageDescriptor) protoreflect.FieldDescriptor {
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		if fd := fields.Get(i); serialFields[fd.FullName()] && !fd.IsList() {
+			return fd
+		}
+	}
+	return nil
+}
+
+func valueEqual(a, b protoreflect.Value) bool {
+	switch va := a.Interface().(type) {
+	case []byte:
+		return string(va) == string(b.Bytes())
+	case protoreflect.EnumNumber:
+		return va == b.Enum()
+	case float32, float64:
+		fa, fb := a.Float(), b.Float()
+		return fa == fb || math.IsNaN(fa) && math.IsNaN(fb)
+	default:
+		return a.Interface() == b.Interface()
+	}
+}
+
+func formatValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return fmt.Sprintf("%q", v.String())
+	case protoreflect.BytesKind:
+		return hex.EncodeToString(v.Bytes())
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return fmt.Sprint(v.Enum())
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// fieldPath appends the Go name of a field to a path, a nil field stands for the unknown fields of a message.
+func fieldPath(parent string, fd protoreflect.FieldDescriptor) string {
+	name := "(unknown fields)"
+	if fd != nil {
+		json := fd.JSONName()
+		name = strings.ToUpper(json[:1]) + json[1:]
+	}
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+/*
+Headers returns the differences between two save headers, with paths like Header.BuildId.
+*/
+func Headers(a, b shared.SavFile) []Change {
+	var changes []Change
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	for i := 0; i < va.NumField(); i++ {
+		fa, fb := va.Field(i).Interface(), vb.Field(i).Interface()
+		if !reflect.DeepEqual(fa, fb) {
+			changes = append(changes, Change{Path: "Header." + va.Type().Field(i).Name, Old: fmt.Sprint(fa), New: fmt.Sprint(fb)})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,181 @@
+// Package document converts saves to and from a stable JSON representation based on protojson.
+//
+// Save data uses the protobuf JSON mapping: fields are named in lowerCamelCase and always present,
+// 64 bit integers are strings, bytes are base64 strings and non-finite floats are "NaN", "Infinity" and "-Infinity".
+// Fields unknown to the pb definitions are carried along in the "unknown" list of the document, see FindUnknownFields.
+// The document layout is described by the JSON Schema returned by CharacterSchema and ProfileSchema.
+package document
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+	"github.com/cfi2017/bl3-save-core/pkg/shared"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Version is the version of the document layout written by this package.
+const Version = 1
+
+// Header is the GVAS header of a save file.
+type Header struct {
+	SgVersion          int                `json:"sgVersion"`
+	PkgVersion         int                `json:"pkgVersion"`
+	EngineMajorVersion int                `json:"engineMajorVersion"`
+	EngineMinorVersion int                `json:"engineMinorVersion"`
+	EnginePatchVersion int                `json:"enginePatchVersion"`
+	EngineBuildVersion int                `json:"engineBuildVersion"`
+	BuildId            string             `json:"buildId"`
+	FmtVersion         int                `json:"fmtVersion"`
+	CustomFormatData   []CustomFormatData `json:"customFormatData"`
+	SgType             string             `json:"sgType"`
+}
+
+// CustomFormatData is a custom version entry of a save header.
+type CustomFormatData struct {
+	Guid  string `json:"guid"`
+	Entry int    `json:"entry"`
+}
+
+// document is the top level layout of character and profile documents.
+type document struct {
+	Version   int             `json:"version"`
+	Save      Header          `json:"save"`
+	Character json.RawMessage `json:"character,omitempty"`
+	Profile   json.RawMessage `json:"profile,omitempty"`
+	Unknown   []UnknownField  `json:"unknown,omitempty"`
+}
+
+var (
+	marshalOptions   = protojson.MarshalOptions{EmitUnpopulated: true}
+	unmarshalOptions = protojson.UnmarshalOptions{}
+)
+
+/*
+MarshalCharacter converts a character save into a JSON document.
+*/
+func MarshalCharacter(s shared.SavFile, c *pb.Character) ([]byte, error) {
+	data, err := marshalOptions.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(document{Version: Version, Save: headerFromSav(s), Character: data, Unknown: FindUnknownFields(c)}, "", "  ")
+}
+
+/*
+UnmarshalCharacter converts a JSON document created by MarshalCharacter back into a character save.
+If unknown fields of removed messages can't be restored, the save is returned along with a *MissingFieldsError.
+*/
+func UnmarshalCharacter(data []byte) (shared.SavFile, *pb.Character, error) {
+	doc, err := parse(data)
+	if err != nil {
+		return shared.SavFile{}, nil, err
+	}
+	if doc.Character == nil {
+		return shared.SavFile{}, nil, fmt.Errorf("document contains no character")
+	}
+	c := &pb.Character{}
+	if err := unmarshal(doc.Character, c); err != nil {
+		return shared.SavFile{}, nil, fmt.Errorf("invalid character: %v", err)
+	}
+	err = RestoreUnknownFields(c, doc.Unknown)
+	return doc.Save.sav(), c, err
+}
+
+/*
+MarshalProfile converts a profile save into a JSON document.
+*/
+func MarshalProfile(s shared.SavFile, p *pb.Profile) ([]byte, error) {
+	data, err := marshalOptions.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(document{Version: Version, Save: headerFromSav(s), Profile: data, Unknown: FindUnknownFields(p)}, "", "  ")
+}
+
+/*
+UnmarshalProfile converts a JSON document created by MarshalProfile back into a profile save.
+If unknown fields of removed messages can't be restored, the save is returned along with a *MissingFieldsError.
+*/
+func UnmarshalProfile(data []byte) (shared.SavFile, *pb.Profile, error) {
+	doc, err := parse(data)
+	if err != nil {
+		return shared.SavFile{}, nil, err
+	}
+	if doc.Profile == nil {
+		return shared.SavFile{}, nil, fmt.Errorf("document contains no profile")
+	}
+	p := &pb.Profile{}
+	if err := unmarshal(doc.Profile, p); err != nil {
+		return shared.SavFile{}, nil, fmt.Errorf("invalid profile: %v", err)
+	}
+	err = RestoreUnknownFields(p, doc.Unknown)
+	return doc.Save.sav(), p, err
+}
+
+/*
+IsProfile reports whether a JSON document contains a profile rather than a character.
+*/
+func IsProfile(data []byte) (bool, error) {
+	doc, err := parse(data)
+	if err != nil {
+		return false, err
+	}
+	return doc.Profile != nil, nil
+}
+
+func parse(data []byte) (document, error) {
+	var doc document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return doc, err
+	}
+	if doc.Version < 1 || doc.Version > Version {
+		return doc, fmt.Errorf("unsupported document version %d", doc.Version)
+	}
+	return doc, nil
+}
+
+func unmarshal(data []byte, m proto.Message) error {
+	return unmarshalOptions.Unmarshal(data, m)
+}
+
+func headerFromSav(s shared.SavFile) Header {
+	h := Header{
+		SgVersion:          s.SgVersion,
+		PkgVersion:         s.PkgVersion,
+		EngineMajorVersion: s.EngineMajorVersion,
+		EngineMinorVersion: s.EngineMinorVersion,
+		EnginePatchVersion: s.EnginePatchVersion,
+		EngineBuildVersion: s.EngineBuildVersion,
+		BuildId:            s.BuildId,
+		FmtVersion:         s.FmtVersion,
+		CustomFormatData:   make([]CustomFormatData, len(s.CustomFmtData)),
+		SgType:             s.SgType,
+	}
+	for i, d := range s.CustomFmtData {
+		h.CustomFormatData[i] = CustomFormatData{Guid: d.Guid, Entry: d.Entry}
+	}
+	return h
+}
+
+func (h Header) sav() shared.SavFile {
+	s := shared.SavFile{
+		SgVersion:          h.SgVersion,
+		PkgVersion:         h.PkgVersion,
+		EngineMajorVersion: h.EngineMajorVersion,
+		EngineMinorVersion: h.EngineMinorVersion,
+		EnginePatchVersion: h.EnginePatchVersion,
+		EngineBuildVersion: h.EngineBuildVersion,
+		BuildId:            h.BuildId,
+		FmtVersion:         h.FmtVersion,
+		FmtCount:           len(h.CustomFormatData),
+		CustomFmtData:      make([]shared.CustomFormatData, len(h.CustomFormatData)),
+		SgType:             h.SgType,
+	}
+	for i, d := range h.CustomFormatData {
+		s.CustomFmtData[i] = shared.CustomFormatData{Guid: d.Guid, Entry: d.Entry}
+	}
+	return s
+}
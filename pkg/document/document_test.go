@@ -0,0 +1,130 @@
+package document
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+	"github.com/cfi2017/bl3-save-core/pkg/shared"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCharacterRoundTrip(t *testing.T) {
+	s := shared.SavFile{
+		SgVersion:     2,
+		BuildId:       "OAK-PATCHDIESEL1-280",
+		FmtCount:      1,
+		CustomFmtData: []shared.CustomFormatData{{Guid: "0123456789abcdef0123456789abcdef", Entry: 1}},
+		SgType:        "OakSaveGame",
+	}
+	c := &pb.Character{
+		SaveGameId:        3,
+		LastSaveTimestamp: math.MaxInt64,
+		GbxZoneMapFodSaveGameData: &pb.GbxZoneMapFODSaveGameData{
+			LevelData: []*pb.GbxZoneMapFODSavedLevelData{
+				{LevelName: "Sanctuary3_P", DiscoveryPercentage: float32(math.Inf(1))},
+				{LevelName: "Prologue_P", DiscoveryPercentage: float32(math.NaN())},
+			},
+		},
+	}
+	data, err := MarshalCharacter(s, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"Infinity"`, `"NaN"`, `"9223372036854775807"`} {
+		if !bytes.Contains(data, []byte(want)) {
+			t.Errorf("document doesn't contain %s", want)
+		}
+	}
+	if !json.Valid(data) {
+		t.Fatal("document is not valid json")
+	}
+	s2, c2, err := UnmarshalCharacter(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s2.BuildId != s.BuildId || s2.FmtCount != 1 || s2.CustomFmtData[0] != s.CustomFmtData[0] {
+		t.Errorf("header changed: %+v", s2)
+	}
+	// NaN != NaN, compare the wire format instead
+	want, _ := proto.Marshal(c)
+	got, _ := proto.Marshal(c2)
+	if !bytes.Equal(want, got) {
+		t.Error("character changed in round trip")
+	}
+	if _, _, err := UnmarshalProfile(data); err == nil {
+		t.Error("expected error reading character document as profile")
+	}
+}
+
+func TestSchemaFiles(t *testing.T) {
+	for name, generate := range map[string]func() ([]byte, error){
+		"character.schema.json": CharacterSchema,
+		"profile.schema.json":   ProfileSchema,
+	} {
+		data, err := generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		published, err := ioutil.ReadFile(filepath.Join("..", "..", "schema", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.TrimSpace(string(published)) != string(data) {
+			t.Errorf("schema/%s is out of date, run go generate", name)
+		}
+	}
+}
+
+func TestUnknownFieldSchema(t *testing.T) {
+	properties := unknownFieldSchema()["properties"].(schema)
+	typ := reflect.TypeOf(UnknownField{})
+	for i := 0; i < typ.NumField(); i++ {
+		name := strings.Split(typ.Field(i).Tag.Get("json"), ",")[0]
+		if _, ok := properties[name]; !ok {
+			t.Errorf("schema of unknown fields doesn't allow %s", name)
+		}
+	}
+}
+
+func TestSchemaEnums(t *testing.T) {
+	c := &pb.Character{MissionPlaythroughsData: []*pb.MissionPlaythroughSaveGameData{{
+		MissionList: []*pb.MissionStatusPlayerSaveGameData{{Status: 42}},
+	}}}
+	data, err := MarshalCharacter(shared.SavFile{}, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(data, []byte(`"status": 42`)) {
+		t.Fatal("unknown enum value isn't written as number")
+	}
+	data, err = CharacterSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s struct {
+		Definitions map[string]struct {
+			Properties map[string]struct {
+				OneOf []struct {
+					Type string `json:"type"`
+				} `json:"oneOf"`
+			} `json:"properties"`
+		} `json:"definitions"`
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		t.Fatal(err)
+	}
+	types := make([]string, 0)
+	for _, alternative := range s.Definitions["OakSave.MissionStatusPlayerSaveGameData"].Properties["status"].OneOf {
+		types = append(types, alternative.Type)
+	}
+	if !reflect.DeepEqual(types, []string{"string", "integer"}) {
+		t.Fatalf("unexpected enum schema types %v", types)
+	}
+}
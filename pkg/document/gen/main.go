@@ -0,0 +1,28 @@
+// Command gen writes the JSON Schema files of character and profile documents.
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+
+	"github.com/cfi2017/bl3-save-core/pkg/document"
+)
+
+func main() {
+	out := flag.String("o", ".", "output directory")
+	flag.Parse()
+	write(filepath.Join(*out, "character.schema.json"), document.CharacterSchema)
+	write(filepath.Join(*out, "profile.schema.json"), document.ProfileSchema)
+}
+
+func write(path string, generate func() ([]byte, error)) {
+	data, err := generate()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,323 @@
+package document
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Operation is a single JSON Patch operation as defined by RFC 6902.
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Patch is a list of operations that are applied in order.
+type Patch []Operation
+
+/*
+ParsePatch reads a JSON Patch document and checks that its operations are complete.
+*/
+func ParsePatch(data []byte) (Patch, error) {
+	var patch Patch
+	if err := json.Unmarshal(data, &patch); err != nil {
+		return nil, err
+	}
+	for i, op := range patch {
+		switch op.Op {
+		case "add", "replace", "test":
+			if op.Value == nil {
+				return nil, fmt.Errorf("operation %d: %s requires a value", i, op.Op)
+			}
+		case "move", "copy":
+			if _, err := parsePointer(op.From); err != nil {
+				return nil, fmt.Errorf("operation %d: %v", i, err)
+			}
+		case "remove":
+		default:
+			return nil, fmt.Errorf("operation %d: unknown op %q", i, op.Op)
+		}
+		if _, err := parsePointer(op.Path); err != nil {
+			return nil, fmt.Errorf("operation %d: %v", i, err)
+		}
+	}
+	return patch, nil
+}
+
+/*
+ApplyPatch applies a JSON Patch to a character or profile.
+Paths refer to the JSON form of the message described by the published schema, e.g. /mayhemLevel.
+As every field is present in that form, paths to fields that don't exist are rejected.
+The message is only changed if all operations succeed. Unknown fields of the message are kept,
+fields of removed entries are dropped with them and reported as a *MissingFieldsError after the patch was applied.
+*/
+func ApplyPatch(m proto.Message, patch Patch) error {
+	data, err := marshalOptions.Marshal(m)
+	if err != nil {
+		return err
+	}
+	var doc interface{}
+	if err := decodeJSON(data, &doc); err != nil {
+		return err
+	}
+	for i, op := range patch {
+		if doc, err = apply(doc, op); err != nil {
+			return fmt.Errorf("operation %d (%s %s): %v", i, op.Op, op.Path, err)
+		}
+	}
+	data, err = json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	result := m.ProtoReflect().New().Interface()
+	if err := unmarshal(data, result); err != nil {
+		return fmt.Errorf("patched document is invalid: %v", err)
+	}
+	err = RestoreUnknownFields(result, FindUnknownFields(m))
+	var missing *MissingFieldsError
+	if err != nil && !errors.As(err, &missing) {
+		return err
+	}
+	proto.Reset(m)
+	proto.Merge(m, result)
+	return err
+}
+
+func apply(doc interface{}, op Operation) (interface{}, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+	switch op.Op {
+	case "add", "replace", "test":
+		var value interface{}
+		if err := decodeJSON(op.Value, &value); err != nil {
+			return nil, err
+		}
+		switch op.Op {
+		case "add":
+			return add(doc, path, value)
+		case "replace":
+			if _, err := get(doc, path); err != nil {
+				return nil, err
+			}
+			if doc, err = remove(doc, path); err != nil {
+				return nil, err
+			}
+			return add(doc, path, value)
+		}
+		current, err := get(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(normalize(current), normalize(value)) {
+			return nil, fmt.Errorf("test failed")
+		}
+		return doc, nil
+	case "remove":
+		return remove(doc, path)
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := get(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		if op.Op == "move" {
+			if strings.HasPrefix(op.Path+"/", op.From+"/") && op.Path != op.From {
+				return nil, fmt.Errorf("can't move a value into itself")
+			}
+			if doc, err = remove(doc, from); err != nil {
+				return nil, err
+			}
+		} else {
+			value = deepCopy(value)
+		}
+		return add(doc, path, value)
+	}
+	return nil, fmt.Errorf("unknown op %q", op.Op)
+}
+
+// parsePointer splits a JSON Pointer (RFC 6901) into its unescaped tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid path %q", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func get(doc interface{}, path []string) (interface{}, error) {
+	for i, token := range path {
+		switch v := doc.(type) {
+		case map[string]interface{}:
+			child, ok := v[token]
+			if !ok {
+				return nil, fmt.Errorf("%s doesn't exist", pointerString(path[:i+1]))
+			}
+			doc = child
+		case []interface{}:
+			index, err := arrayIndex(token, len(v))
+			if err != nil || index == len(v) {
+				return nil, fmt.Errorf("%s doesn't exist", pointerString(path[:i+1]))
+			}
+			doc = v[index]
+		default:
+			return nil, fmt.Errorf("%s doesn't exist", pointerString(path[:i+1]))
+		}
+	}
+	return doc, nil
+}
+
+// add inserts a value, returning the new document. Only existing members may be replaced as messages have a fixed set of fields.
+func add(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+	switch v := parent.(type) {
+	case map[string]interface{}:
+		v[last] = value
+		return doc, nil
+	case []interface{}:
+		index, err := arrayIndex(last, len(v))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", pointerString(path), err)
+		}
+		v = append(v, nil)
+		copy(v[index+1:], v[index:])
+		v[index] = value
+		return set(doc, path[:len(path)-1], v)
+	}
+	return nil, fmt.Errorf("%s is not an object or array", pointerString(path[:len(path)-1]))
+}
+
+func remove(doc interface{}, path []string) (interface{}, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("can't remove the whole document")
+	}
+	if _, err := get(doc, path); err != nil {
+		return nil, err
+	}
+	parent, _ := get(doc, path[:len(path)-1])
+	last := path[len(path)-1]
+	switch v := parent.(type) {
+	case map[string]interface{}:
+		delete(v, last)
+		return doc, nil
+	case []interface{}:
+		index, _ := arrayIndex(last, len(v))
+		return set(doc, path[:len(path)-1], append(v[:index:index], v[index+1:]...))
+	}
+	return doc, nil
+}
+
+// set replaces the value at an existing path, used to store arrays that changed length.
+func set(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+	switch v := parent.(type) {
+	case map[string]interface{}:
+		v[last] = value
+	case []interface{}:
+		index, _ := arrayIndex(last, len(v))
+		v[index] = value
+	}
+	return doc, nil
+}
+
+// arrayIndex parses an array index, "-" is the end of the array.
+func arrayIndex(token string, length int) (int, error) {
+	if token == "-" {
+		return length, nil
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || index > length || token != strconv.Itoa(index) {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	return index, nil
+}
+
+func pointerString(path []string) string {
+	var b strings.Builder
+	for _, token := range path {
+		b.WriteString("/")
+		b.WriteString(strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1"))
+	}
+	return b.String()
+}
+
+// decodeJSON decodes JSON keeping numbers as written, so large integers don't lose precision.
+func decodeJSON(data []byte, v interface{}) error {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	return d.Decode(v)
+}
+
+// normalize converts numbers to a canonical form for comparisons, e.g. 1.0 and 1 are equal.
+func normalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for k, child := range v {
+			result[k] = normalize(child)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, child := range v {
+			result[i] = normalize(child)
+		}
+		return result
+	}
+	return v
+}
+
+func deepCopy(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for k, child := range v {
+			result[k] = deepCopy(child)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, child := range v {
+			result[i] = deepCopy(child)
+		}
+		return result
+	}
+	return v
+}
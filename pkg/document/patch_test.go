@@ -0,0 +1,86 @@
+package document
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+func TestApplyPatch(t *testing.T) {
+	c := &pb.Character{
+		MayhemLevel:      3,
+		ExperiencePoints: 100,
+		SduList:          []*pb.OakSDUSaveGameData{{SduLevel: 1, SduDataPath: "/Game/Pawns/SDU/SDU_Backpack.SDU_Backpack"}},
+	}
+	patch, err := ParsePatch([]byte(`[
+		{"op": "test", "path": "/mayhemLevel", "value": 3},
+		{"op": "replace", "path": "/mayhemLevel", "value": 10},
+		{"op": "replace", "path": "/sduList/0/sduLevel", "value": 13},
+		{"op": "add", "path": "/sduList/-", "value": {"sduLevel": 5, "sduDataPath": "/Game/Pawns/SDU/SDU_Bank.SDU_Bank"}},
+		{"op": "copy", "from": "/experiencePoints", "path": "/playthroughsCompleted"}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ApplyPatch(c, patch); err != nil {
+		t.Fatal(err)
+	}
+	if c.MayhemLevel != 10 || len(c.SduList) != 2 || c.SduList[0].SduLevel != 13 || c.SduList[1].SduLevel != 5 ||
+		c.PlaythroughsCompleted != 100 {
+		t.Fatalf("patch not applied: %v", c)
+	}
+
+	for _, invalid := range []string{
+		`[{"op": "replace", "path": "/mayhemLvl", "value": 10}]`,
+		`[{"op": "add", "path": "/mayhemLvl", "value": 10}]`,
+		`[{"op": "remove", "path": "/sduList/5"}]`,
+		`[{"op": "test", "path": "/mayhemLevel", "value": 3}]`,
+		`[{"op": "replace", "path": "/mayhemLevel", "value": "ten"}]`,
+	} {
+		patch, err := ParsePatch([]byte(invalid))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ApplyPatch(c, patch); err == nil {
+			t.Errorf("expected error applying %s", invalid)
+		}
+	}
+	if c.MayhemLevel != 10 || len(c.SduList) != 2 {
+		t.Fatal("failed patch changed the character")
+	}
+	if _, err := ParsePatch([]byte(`[{"op": "set", "path": "/mayhemLevel"}]`)); err == nil {
+		t.Error("expected error parsing unknown op")
+	}
+}
+
+func TestApplyPatchUnknownFields(t *testing.T) {
+	c := &pb.Character{SduList: []*pb.OakSDUSaveGameData{
+		{SduLevel: 1, SduDataPath: "/Game/Pawns/SDU/SDU_Backpack.SDU_Backpack"},
+		{SduLevel: 1, SduDataPath: "/Game/Pawns/SDU/SDU_Pistol.SDU_Pistol"},
+	}}
+	c.SduList[0].ProtoReflect().SetUnknown([]byte{0xa0, 0x38, 0x01})
+	c.SduList[1].ProtoReflect().SetUnknown([]byte{0xa0, 0x38, 0x02})
+	patch, err := ParsePatch([]byte(`[{"op": "replace", "path": "/sduList/0/sduLevel", "value": 8}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ApplyPatch(c, patch); err != nil {
+		t.Fatal(err)
+	}
+	if c.SduList[0].SduLevel != 8 || !bytes.Equal(c.SduList[0].ProtoReflect().GetUnknown(), []byte{0xa0, 0x38, 0x01}) {
+		t.Fatal("unknown fields of the edited entry were lost")
+	}
+
+	if patch, err = ParsePatch([]byte(`[{"op": "remove", "path": "/sduList/1"}]`)); err != nil {
+		t.Fatal(err)
+	}
+	var missing *MissingFieldsError
+	if err := ApplyPatch(c, patch); !errors.As(err, &missing) {
+		t.Fatalf("expected missing fields error, got %v", err)
+	}
+	if len(c.SduList) != 1 || !bytes.Equal(c.SduList[0].ProtoReflect().GetUnknown(), []byte{0xa0, 0x38, 0x01}) {
+		t.Fatal("patch removing an entry wasn't applied")
+	}
+}
@@ -0,0 +1,171 @@
+package document
+
+import (
+	"encoding/json"
+
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+//go:generate go run ./gen -o ../../schema
+
+const schemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// schema is a JSON Schema object. Maps are marshalled with sorted keys, which keeps the output stable.
+type schema map[string]interface{}
+
+/*
+CharacterSchema returns the JSON Schema of character documents.
+*/
+func CharacterSchema() ([]byte, error) {
+	return documentSchema("character", "BL3 character save", (&pb.Character{}).ProtoReflect().Descriptor())
+}
+
+/*
+ProfileSchema returns the JSON Schema of profile documents.
+*/
+func ProfileSchema() ([]byte, error) {
+	return documentSchema("profile", "BL3 profile save", (&pb.Profile{}).ProtoReflect().Descriptor())
+}
+
+func documentSchema(property, title string, md protoreflect.MessageDescriptor) ([]byte, error) {
+	defs := schema{"Header": headerSchema(), "UnknownField": unknownFieldSchema()}
+	addMessage(defs, md)
+	root := schema{
+		"$schema":              schemaDraft,
+		"title":                title,
+		"type":                 "object",
+		"additionalProperties": false,
+		"required":             []string{"version", "save", property},
+		"properties": schema{
+			"version": schema{"type": "integer", "minimum": 1, "maximum": Version},
+			"save":    ref("Header"),
+			property:  ref(string(md.FullName())),
+			"unknown": schema{"type": "array", "items": ref("UnknownField")},
+		},
+		"definitions": defs,
+	}
+	return json.MarshalIndent(root, "", "  ")
+}
+
+func headerSchema() schema {
+	integer := schema{"type": "integer"}
+	return schema{
+		"type":                 "object",
+		"additionalProperties": false,
+		"properties": schema{
+			"sgVersion":          integer,
+			"pkgVersion":         integer,
+			"engineMajorVersion": integer,
+			"engineMinorVersion": integer,
+			"enginePatchVersion": integer,
+			"engineBuildVersion": integer,
+			"buildId":            schema{"type": "string"},
+			"fmtVersion":         integer,
+			"customFormatData": schema{
+				"type": "array",
+				"items": schema{
+					"type":                 "object",
+					"additionalProperties": false,
+					"properties": schema{
+						"guid":  schema{"type": "string"},
+						"entry": integer,
+					},
+				},
+			},
+			"sgType": schema{"type": "string"},
+		},
+	}
+}
+
+func unknownFieldSchema() schema {
+	return schema{
+		"type":                 "object",
+		"additionalProperties": false,
+		"required":             []string{"path", "data"},
+		"properties": schema{
+			"path": schema{"type": "string"},
+			"key":  schema{"type": "string"},
+			"data": schema{"type": "string", "contentEncoding": "base64"},
+		},
+	}
+}
+
+// addMessage adds the definition of a message and all messages it references.
+func addMessage(defs schema, md protoreflect.MessageDescriptor) {
+	name := string(md.FullName())
+	if _, ok := defs[name]; ok {
+		return
+	}
+	properties := schema{}
+	def := schema{
+		"type":                 "object",
+		"additionalProperties": false,
+		"properties":           properties,
+	}
+	defs[name] = def
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		properties[fd.JSONName()] = fieldSchema(defs, fd)
+	}
+}
+
+func fieldSchema(defs schema, fd protoreflect.FieldDescriptor) schema {
+	switch {
+	case fd.IsMap():
+		// protojson writes map keys as strings, whatever their type
+		return schema{"type": "object", "additionalProperties": valueSchema(defs, fd.MapValue())}
+	case fd.IsList():
+		return schema{"type": "array", "items": valueSchema(defs, fd)}
+	}
+	s := valueSchema(defs, fd)
+	if fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind {
+		// unset messages are written as null
+		return schema{"oneOf": []schema{s, {"type": "null"}}}
+	}
+	return s
+}
+
+// valueSchema returns the schema of a single value of a field, following the protobuf JSON mapping.
+func valueSchema(defs schema, fd protoreflect.FieldDescriptor) schema {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return schema{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return schema{"type": "integer", "minimum": int64(-1 << 31), "maximum": int64(1<<31 - 1)}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return schema{"type": "integer", "minimum": 0, "maximum": uint64(1<<32 - 1)}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return schema{"type": "string", "pattern": "^-?[0-9]+$"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return schema{"type": "string", "pattern": "^[0-9]+$"}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return schema{"oneOf": []schema{
+			{"type": "number"},
+			{"type": "string", "enum": []string{"NaN", "Infinity", "-Infinity"}},
+		}}
+	case protoreflect.StringKind:
+		return schema{"type": "string"}
+	case protoreflect.BytesKind:
+		return schema{"type": "string", "contentEncoding": "base64"}
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		names := make([]string, values.Len())
+		for i := range names {
+			names[i] = string(values.Get(i).Name())
+		}
+		// values the pb definitions don't know about are written as numbers
+		return schema{"oneOf": []schema{
+			{"type": "string", "enum": names},
+			{"type": "integer", "minimum": int64(-1 << 31), "maximum": int64(1<<31 - 1)},
+		}}
+	default:
+		addMessage(defs, fd.Message())
+		return ref(string(fd.Message().FullName()))
+	}
+}
+
+func ref(name string) schema {
+	return schema{"$ref": "#/definitions/" + name}
+}
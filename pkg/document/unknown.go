@@ -0,0 +1,359 @@
+package document
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cfi2017/bl3-save-core/pkg/shared"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// UnknownField holds the fields of a message that aren't part of the pb definitions, in wire format.
+// Path locates the message using the JSON field names, e.g. "missionPlaythroughsData[0].missionList[3]".
+// The path of the save itself is empty.
+// Key identifies messages in lists and maps, so their fields follow them when entries are removed or reordered.
+// It is the message's serial, GUID or asset path if it has one, a hash of its content otherwise.
+type UnknownField struct {
+	Path string `json:"path"`
+	Key  string `json:"key,omitempty"`
+	Data []byte `json:"data"`
+}
+
+// MissingFieldsError is returned by RestoreUnknownFields for fields whose message no longer exists,
+// usually because the list entry holding them was removed.
+type MissingFieldsError struct {
+	Paths []string
+}
+
+func (e *MissingFieldsError) Error() string {
+	return fmt.Sprintf("couldn't restore unknown fields: %s", strings.Join(e.Paths, ", "))
+}
+
+// keyFields are the JSON names of fields that identify a message, in order of preference.
+var keyFields = []string{
+	"itemSerialNumber", "encryptedSerialNumber", "mailGuid", "guid",
+	"missionClassPath", "challengeClassPath", "echoLogPath", "assetPath",
+}
+
+/*
+FindUnknownFields returns the unknown fields of a message and all messages it contains.
+Saves written by newer versions of the game can contain fields the pb definitions don't know about.
+These are kept when decoding the binary save, but are lost when converting it to JSON.
+*/
+func FindUnknownFields(m proto.Message) []UnknownField {
+	var fields []UnknownField
+	findUnknownFields(m.ProtoReflect(), "", &fields)
+	return fields
+}
+
+func findUnknownFields(m protoreflect.Message, path string, fields *[]UnknownField) {
+	if data := m.GetUnknown(); len(data) > 0 {
+		f := UnknownField{Path: path, Data: append([]byte{}, data...)}
+		if strings.Contains(path, "[") {
+			f.Key = messageKey(m)
+		}
+		*fields = append(*fields, f)
+	}
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		name := fieldPath(path, fd.JSONName())
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Message() == nil {
+				break
+			}
+			v.Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+				findUnknownFields(v.Message(), fmt.Sprintf("%s[%s]", name, k.String()), fields)
+				return true
+			})
+		case fd.IsList():
+			if fd.Message() == nil {
+				break
+			}
+			l := v.List()
+			for i := 0; i < l.Len(); i++ {
+				findUnknownFields(l.Get(i).Message(), fmt.Sprintf("%s[%d]", name, i), fields)
+			}
+		case fd.Message() != nil:
+			findUnknownFields(v.Message(), name, fields)
+		}
+		return true
+	})
+}
+
+/*
+RestoreUnknownFields puts unknown fields found by FindUnknownFields back into a message.
+Unknown fields already present in the message are replaced. Fields with a key are restored into the message with
+that key, wherever it moved to in its list. Fields whose key isn't found anymore, usually because the entry was
+edited in place, are restored into the message at their path if it didn't get other fields restored.
+Fields whose message no longer exists are reported as a *MissingFieldsError after all other fields were restored.
+*/
+func RestoreUnknownFields(m proto.Message, fields []UnknownField) error {
+	var missing []string
+	var edited []UnknownField
+	restored := make(map[protoreflect.Message]bool)
+	for _, f := range fields {
+		target, err := resolvePath(m.ProtoReflect(), f.Path)
+		if f.Key != "" && (err != nil || restored[target] || messageKey(target) != f.Key) {
+			if target, err = findByKey(m.ProtoReflect(), f.Path, f.Key, restored); err != nil {
+				edited = append(edited, f)
+				continue
+			}
+		}
+		if err != nil {
+			missing = append(missing, err.Error())
+			continue
+		}
+		target.SetUnknown(append(protoreflect.RawFields{}, f.Data...))
+		restored[target] = true
+	}
+	// all entries that still have their key got their fields back, the remaining fields belong to the entry at their path
+	for _, f := range edited {
+		target, err := resolvePath(m.ProtoReflect(), f.Path)
+		if err == nil && restored[target] {
+			err = fmt.Errorf("%s: no message with key %s", f.Path, f.Key)
+		}
+		if err != nil {
+			missing = append(missing, err.Error())
+			continue
+		}
+		target.SetUnknown(append(protoreflect.RawFields{}, f.Data...))
+		restored[target] = true
+	}
+	if len(missing) > 0 {
+		return &MissingFieldsError{Paths: missing}
+	}
+	return nil
+}
+
+/*
+messageKey identifies a message by the first set field of keyFields, or by a hash of its known fields.
+*/
+func messageKey(m protoreflect.Message) string {
+	for _, name := range keyFields {
+		fd := findField(m.Descriptor(), name)
+		if fd == nil || fd.IsList() || fd.IsMap() || !m.Has(fd) {
+			continue
+		}
+		switch fd.Kind() {
+		case protoreflect.StringKind:
+			return name + "=" + m.Get(fd).String()
+		case protoreflect.BytesKind:
+			return name + "=" + base64.StdEncoding.EncodeToString(m.Get(fd).Bytes())
+		}
+	}
+	clone := proto.Clone(m.Interface())
+	discardUnknown(clone.ProtoReflect())
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(clone)
+	if err != nil {
+		return ""
+	}
+	sum := sha1.Sum(data)
+	return "sha1=" + hex.EncodeToString(sum[:])
+}
+
+// discardUnknown removes the unknown fields of a message and all messages it contains.
+func discardUnknown(m protoreflect.Message) {
+	m.SetUnknown(nil)
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				v.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+					discardUnknown(v.Message())
+					return true
+				})
+			}
+		case fd.IsList():
+			if fd.Message() != nil {
+				for i := 0; i < v.List().Len(); i++ {
+					discardUnknown(v.List().Get(i).Message())
+				}
+			}
+		case fd.Message() != nil:
+			discardUnknown(v.Message())
+		}
+		return true
+	})
+}
+
+/*
+findByKey searches all messages matching the path with any list index or map key for a message with the given key
+that didn't get its unknown fields restored yet.
+*/
+func findByKey(m protoreflect.Message, path, key string, restored map[protoreflect.Message]bool) (protoreflect.Message, error) {
+	var found protoreflect.Message
+	walkPath(m, stripIndexes(path), func(candidate protoreflect.Message) bool {
+		if !restored[candidate] && messageKey(candidate) == key {
+			found = candidate
+			return false
+		}
+		return true
+	})
+	if found == nil {
+		return nil, fmt.Errorf("%s: no message with key %s", path, key)
+	}
+	return found, nil
+}
+
+// stripIndexes removes list indexes and map keys from a path, e.g. "a[0].b[1]" becomes "a.b".
+func stripIndexes(path string) string {
+	var b strings.Builder
+	depth := 0
+	for _, r := range path {
+		switch {
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+		case depth == 0:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// walkPath calls visit for every message reachable through the field names of path, until visit returns false.
+func walkPath(m protoreflect.Message, path string, visit func(protoreflect.Message) bool) bool {
+	if path == "" {
+		return visit(m)
+	}
+	segment, rest := path, ""
+	if i := strings.Index(path, "."); i >= 0 {
+		segment, rest = path[:i], path[i+1:]
+	}
+	fd := findField(m.Descriptor(), segment)
+	if fd == nil || !m.Has(fd) {
+		return true
+	}
+	switch {
+	case fd.IsMap():
+		if fd.MapValue().Message() == nil {
+			return true
+		}
+		more := true
+		m.Get(fd).Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+			more = walkPath(v.Message(), rest, visit)
+			return more
+		})
+		return more
+	case fd.IsList():
+		if fd.Message() == nil {
+			return true
+		}
+		l := m.Get(fd).List()
+		for i := 0; i < l.Len(); i++ {
+			if !walkPath(l.Get(i).Message(), rest, visit) {
+				return false
+			}
+		}
+		return true
+	case fd.Message() != nil:
+		return walkPath(m.Get(fd).Message(), rest, visit)
+	}
+	return true
+}
+
+// resolvePath finds the message at a path created by findUnknownFields.
+func resolvePath(m protoreflect.Message, path string) (protoreflect.Message, error) {
+	rest := path
+	for rest != "" {
+		var segment string
+		if i := strings.IndexAny(rest, ".["); i < 0 {
+			segment, rest = rest, ""
+		} else {
+			segment, rest = rest[:i], rest[i:]
+		}
+		fd := findField(m.Descriptor(), segment)
+		if fd == nil || fd.Message() == nil {
+			return nil, fmt.Errorf("%s: no message field %s", path, segment)
+		}
+		switch {
+		case fd.IsMap() || fd.IsList():
+			if !strings.HasPrefix(rest, "[") || !strings.Contains(rest, "]") {
+				return nil, fmt.Errorf("%s: missing index of %s", path, segment)
+			}
+			end := strings.Index(rest, "]")
+			key := rest[1:end]
+			rest = rest[end+1:]
+			var v protoreflect.Value
+			var ok bool
+			if fd.IsMap() {
+				v, ok = mapValue(m.Get(fd).Map(), key)
+			} else {
+				v, ok = listValue(m.Get(fd).List(), key)
+			}
+			if !ok {
+				return nil, fmt.Errorf("%s: %s has no entry %s", path, segment, key)
+			}
+			m = v.Message()
+		default:
+			if !m.Has(fd) {
+				return nil, fmt.Errorf("%s: %s is not set", path, segment)
+			}
+			m = m.Mutable(fd).Message()
+		}
+		rest = strings.TrimPrefix(rest, ".")
+	}
+	return m, nil
+}
+
+func findField(md protoreflect.MessageDescriptor, jsonName string) protoreflect.FieldDescriptor {
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		if fields.Get(i).JSONName() == jsonName {
+			return fields.Get(i)
+		}
+	}
+	return nil
+}
+
+func listValue(l protoreflect.List, index string) (protoreflect.Value, bool) {
+	i, err := strconv.Atoi(index)
+	if err != nil || i < 0 || i >= l.Len() {
+		return protoreflect.Value{}, false
+	}
+	return l.Get(i), true
+}
+
+func mapValue(m protoreflect.Map, key string) (protoreflect.Value, bool) {
+	var result protoreflect.Value
+	var ok bool
+	m.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+		if k.String() == key {
+			result, ok = v, true
+			return false
+		}
+		return true
+	})
+	return result, ok
+}
+
+/*
+FieldNumbers returns the field numbers contained in the unknown field data.
+*/
+func (u UnknownField) FieldNumbers() ([]int32, error) {
+	fields, err := shared.SplitWireFields(u.Data)
+	if err != nil {
+		return nil, err
+	}
+	var numbers []int32
+	for _, f := range fields {
+		// skip group end tags
+		if f.Data[0]&7 != 4 {
+			numbers = append(numbers, f.Number)
+		}
+	}
+	return numbers, nil
+}
+
+func fieldPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
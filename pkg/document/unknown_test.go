@@ -0,0 +1,113 @@
+package document
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+	"github.com/cfi2017/bl3-save-core/pkg/shared"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestUnknownFields(t *testing.T) {
+	c := &pb.Character{
+		PreferredCharacterName: "Test",
+		InventoryItems: []*pb.OakInventoryItemSaveGameData{
+			{ItemSerialNumber: []byte{1}},
+			{ItemSerialNumber: []byte{2}},
+		},
+	}
+	// field 900 as varint 1 and field 901 as the string "x"
+	root := []byte{0xa0, 0x38, 0x01, 0xaa, 0x38, 0x01, 'x'}
+	nested := []byte{0xa0, 0x38, 0x02}
+	c.ProtoReflect().SetUnknown(root)
+	c.InventoryItems[1].ProtoReflect().SetUnknown(nested)
+	data, err := proto.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unknown := FindUnknownFields(c)
+	want := []UnknownField{{Path: "", Data: root}, {Path: "inventoryItems[1]", Key: "itemSerialNumber=Ag==", Data: nested}}
+	if !reflect.DeepEqual(unknown, want) {
+		t.Fatalf("unexpected unknown fields: %+v", unknown)
+	}
+	if numbers, err := unknown[0].FieldNumbers(); err != nil || !reflect.DeepEqual(numbers, []int32{900, 901}) {
+		t.Errorf("unexpected field numbers %v: %v", numbers, err)
+	}
+
+	doc, err := MarshalCharacter(shared.SavFile{}, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, c2, err := UnmarshalCharacter(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := proto.Marshal(c2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, got) {
+		t.Error("unknown fields lost in round trip")
+	}
+
+	c2.InventoryItems = c2.InventoryItems[:1]
+	var missing *MissingFieldsError
+	if err := RestoreUnknownFields(c2, unknown); !errors.As(err, &missing) || len(missing.Paths) != 1 {
+		t.Errorf("expected error restoring fields of a removed item, got %v", err)
+	}
+}
+
+func TestUnknownFieldsFollowEntries(t *testing.T) {
+	c := &pb.Character{
+		InventoryItems: []*pb.OakInventoryItemSaveGameData{{ItemSerialNumber: []byte{1}}, {ItemSerialNumber: []byte{2}}, {ItemSerialNumber: []byte{3}}},
+		MissionPlaythroughsData: []*pb.MissionPlaythroughSaveGameData{{MissionList: []*pb.MissionStatusPlayerSaveGameData{
+			{MissionClassPath: "/Game/Missions/A.A_C"}, {MissionClassPath: "/Game/Missions/B.B_C"},
+		}}},
+		ChallengeData: []*pb.ChallengeSaveGameData{{CompletedCount: 1}, {CompletedCount: 2}},
+	}
+	c.InventoryItems[2].ProtoReflect().SetUnknown([]byte{0xa0, 0x38, 0x03})
+	c.MissionPlaythroughsData[0].MissionList[1].ProtoReflect().SetUnknown([]byte{0xa0, 0x38, 0x04})
+	c.ChallengeData[1].ProtoReflect().SetUnknown([]byte{0xa0, 0x38, 0x05})
+	unknown := FindUnknownFields(c)
+
+	edited := proto.Clone(c).(*pb.Character)
+	discardUnknown(edited.ProtoReflect())
+	// remove earlier entries, move the challenge to the front and add a new entry in place of the removed item
+	edited.InventoryItems = []*pb.OakInventoryItemSaveGameData{{ItemSerialNumber: []byte{4}}, edited.InventoryItems[2]}
+	edited.MissionPlaythroughsData[0].MissionList = edited.MissionPlaythroughsData[0].MissionList[1:]
+	edited.ChallengeData = []*pb.ChallengeSaveGameData{edited.ChallengeData[1], edited.ChallengeData[0]}
+	if err := RestoreUnknownFields(edited, unknown); err != nil {
+		t.Fatal(err)
+	}
+	if len(edited.InventoryItems[0].ProtoReflect().GetUnknown()) != 0 ||
+		!bytes.Equal(edited.InventoryItems[1].ProtoReflect().GetUnknown(), []byte{0xa0, 0x38, 0x03}) {
+		t.Error("unknown fields of the item didn't follow it")
+	}
+	if !bytes.Equal(edited.MissionPlaythroughsData[0].MissionList[0].ProtoReflect().GetUnknown(), []byte{0xa0, 0x38, 0x04}) {
+		t.Error("unknown fields of the mission didn't follow it")
+	}
+	if !bytes.Equal(edited.ChallengeData[0].ProtoReflect().GetUnknown(), []byte{0xa0, 0x38, 0x05}) ||
+		len(edited.ChallengeData[1].ProtoReflect().GetUnknown()) != 0 {
+		t.Error("unknown fields of the challenge didn't follow it")
+	}
+
+	// entries edited in place no longer match their key and keep their fields by path
+	edited = proto.Clone(c).(*pb.Character)
+	discardUnknown(edited.ProtoReflect())
+	edited.ChallengeData[1].CompletedCount = 3
+	edited.InventoryItems[2].ItemSerialNumber = []byte{5}
+	if err := RestoreUnknownFields(edited, unknown); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(edited.ChallengeData[1].ProtoReflect().GetUnknown(), []byte{0xa0, 0x38, 0x05}) ||
+		len(edited.ChallengeData[0].ProtoReflect().GetUnknown()) != 0 {
+		t.Error("unknown fields of the edited challenge were lost")
+	}
+	if !bytes.Equal(edited.InventoryItems[2].ProtoReflect().GetUnknown(), []byte{0xa0, 0x38, 0x03}) {
+		t.Error("unknown fields of the re-serialized item were lost")
+	}
+}
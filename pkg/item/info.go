@@ -0,0 +1,110 @@
+package item
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Rarity is the rarity tier of an item, from common (1) to legendary (5).
+// Items whose rarity can't be determined from their balance have rarity 0.
+type Rarity int
+
+const (
+	RarityUnknown Rarity = iota
+	RarityCommon
+	RarityUncommon
+	RarityRare
+	RarityVeryRare
+	RarityLegendary
+)
+
+var (
+	rarityNames         = []string{"unknown", "common", "uncommon", "rare", "veryrare", "legendary"}
+	rarityNumberPattern = regexp.MustCompile(`_0([1-5])(_|\.|$)`)
+	rarityNamePattern   = regexp.MustCompile(`(?i)_(common|uncommon|rare|veryrare|legendary)(_|\.|$)`)
+)
+
+func (r Rarity) String() string {
+	if r < 0 || int(r) >= len(rarityNames) {
+		return rarityNames[RarityUnknown]
+	}
+	return rarityNames[r]
+}
+
+// ParseRarity returns the rarity with the given name (e.g. legendary), or RarityUnknown.
+func ParseRarity(name string) Rarity {
+	for i, n := range rarityNames {
+		if strings.EqualFold(n, name) {
+			return Rarity(i)
+		}
+	}
+	return RarityUnknown
+}
+
+/*
+GetRarity derives the rarity of an item from its balance name (e.g. Balance_PS_JAK_04_VeryRare).
+*/
+func GetRarity(i Item) Rarity {
+	name := GetPartSuffix(i.Balance)
+	if m := rarityNamePattern.FindStringSubmatch(name); m != nil {
+		return ParseRarity(m[1])
+	}
+	if m := rarityNumberPattern.FindStringSubmatch(name); m != nil {
+		r, _ := strconv.Atoi(m[1])
+		return Rarity(r)
+	}
+	if strings.Contains(i.Balance, "/Legendary/") || strings.Contains(i.Balance, "/_Unique/") {
+		return RarityLegendary
+	}
+	return RarityUnknown
+}
+
+/*
+GetCategory derives the gear category of an item from its inventory data path,
+e.g. Pistols for /Game/Gear/Weapons/Pistols/... or Shields for /Game/Gear/Shields/...
+*/
+func GetCategory(i Item) string {
+	path := i.InvData
+	if path == "" {
+		path = i.Balance
+	}
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for index, part := range parts {
+		if part != "Gear" || index+1 >= len(parts) {
+			continue
+		}
+		if parts[index+1] == "Weapons" && index+2 < len(parts) {
+			return parts[index+2]
+		}
+		return parts[index+1]
+	}
+	return ""
+}
+
+/*
+Equal reports whether two items have the same decoded content. Seeds, wrappers and serial versions are ignored, the overflow bits are compared.
+Items that couldn't be decoded are compared by their serial.
+*/
+func Equal(a, b Item) bool {
+	if a.Balance == "" || b.Balance == "" {
+		return a.Balance == b.Balance && a.Wrapper != nil && b.Wrapper != nil &&
+			reflect.DeepEqual(a.Wrapper.ItemSerialNumber, b.Wrapper.ItemSerialNumber)
+	}
+	return a.Level == b.Level && a.Balance == b.Balance && a.Manufacturer == b.Manufacturer &&
+		a.InvData == b.InvData && a.Version == b.Version && a.Overflow == b.Overflow &&
+		stringsEqual(a.Parts, b.Parts) && stringsEqual(a.Generics, b.Generics)
+}
+
+func stringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -37,8 +37,8 @@ func DecryptSerial(data []byte) ([]byte, error) {
 	}
 	seed := int32(binary.BigEndian.Uint32(data[1:])) // next four bytes of serial are bogo seed
 	decrypted := item.BogoDecrypt(seed, data[5:])
-	crc := binary.BigEndian.Uint16(decrypted)                          // first two bytes of decrypted data are crc checksum
-	combined := append(append(data[:5], 0xFF, 0xFF), decrypted[2:]...) // combined data with checksum replaced with 0xFF to compute checksum
+	crc := binary.BigEndian.Uint16(decrypted)                                    // first two bytes of decrypted data are crc checksum
+	combined := append(append(makeCopy(data[:5]), 0xFF, 0xFF), decrypted[2:]...) // combined data with checksum replaced with 0xFF to compute checksum
 	computedChecksum := crc32.ChecksumIEEE(combined)
 	check := uint16(((computedChecksum) >> 16) ^ ((computedChecksum & 0xFFFF) >> 0))
 
@@ -146,6 +146,54 @@ func Deserialize(data []byte) (i Item, err error) {
 	return
 }
 
+// DatabaseError is returned by Decode if the item database isn't available.
+type DatabaseError struct {
+	Err error
+}
+
+func (e *DatabaseError) Error() string {
+	return "item database not available: " + e.Err.Error()
+}
+
+func (e *DatabaseError) Unwrap() error {
+	return e.Err
+}
+
+/*
+Decode is like Deserialize, but returns errors instead of panicking.
+A missing or broken database is reported as a *DatabaseError, a malformed serial as any other error.
+As with Deserialize, items of unknown categories are returned with SkipIntrospection set along with an error.
+*/
+func Decode(data []byte) (i Item, err error) {
+	if err := checkDatabase(); err != nil {
+		return i, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			i, err = Item{}, fmt.Errorf("invalid serial: %v", r)
+		}
+	}()
+	if len(data) == 0 {
+		return i, errors.New("invalid serial length")
+	}
+	return Deserialize(data)
+}
+
+// checkDatabase loads the database, converting the panics of the asset loader into a *DatabaseError.
+func checkDatabase() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &DatabaseError{Err: fmt.Errorf("%v", r)}
+		}
+	}()
+	if assets.DefaultAssetLoader == nil {
+		return &DatabaseError{Err: errors.New("no database set")}
+	}
+	assets.GetDB()
+	assets.GetBtik()
+	return nil
+}
+
 func makeCopy(data []byte) []byte {
 	tmp := make([]byte, len(data))
 	copy(tmp, data)
@@ -251,3 +299,38 @@ func Serialize(i Item, seed int32) ([]byte, error) {
 	return EncryptSerial(w.GetBytes(), seed, i.SerialVersion)
 
 }
+
+/*
+Reseed encrypts a serial number with a new seed without changing the item.
+This does not require a database.
+*/
+func Reseed(data []byte, seed int32) ([]byte, error) {
+	decrypted, err := DecryptSerial(makeCopy(data))
+	if err != nil {
+		return nil, err
+	}
+	return EncryptSerial(decrypted, seed, data[0])
+}
+
+/*
+Reserialize serializes an item with the seed of its wrapped serial number, or seed 0 if it has none.
+Items without a balance, i.e. items that couldn't be decoded, are returned as their original serial number.
+*/
+func Reserialize(i Item) ([]byte, error) {
+	var serial []byte
+	if i.Wrapper != nil {
+		serial = i.Wrapper.ItemSerialNumber
+	}
+	if i.Balance == "" {
+		if serial == nil {
+			return nil, errors.New("item has neither a balance nor a serial number")
+		}
+		return serial, nil
+	}
+	seed, err := GetSeedFromSerial(serial)
+	if err != nil {
+		// set seed to be 0
+		seed = 0
+	}
+	return Serialize(i, seed)
+}
@@ -3,10 +3,13 @@ package item
 import (
 	"encoding/base64"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"log"
 	"reflect"
 	"testing"
+
+	"github.com/cfi2017/bl3-save-core/pkg/assets"
 )
 
 var checks = []string{
@@ -223,3 +226,17 @@ func TestAddAnointment(t *testing.T) {
 	}
 
 }
+
+func TestDecodeWithoutDatabase(t *testing.T) {
+	loader := assets.DefaultAssetLoader
+	defer func() { assets.DefaultAssetLoader = loader }()
+	assets.DefaultAssetLoader = &assets.StaticFileAssetLoader{Pwd: "does-not-exist"}
+	bs, err := base64.StdEncoding.DecodeString(checks[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dbErr *DatabaseError
+	if _, err := Decode(bs); !errors.As(err, &dbErr) {
+		t.Fatalf("expected database error, got %v", err)
+	}
+}
@@ -0,0 +1,240 @@
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/cfi2017/bl3-save-core/pkg/assets"
+	"github.com/cfi2017/bl3-save-core/pkg/item"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+var (
+	// ErrBankFull is returned when an item is added to a bank that has no free slots.
+	ErrBankFull = errors.New("bank is full")
+	// ErrLostLootFull is returned when an item is added to a lost loot machine that has no free slots.
+	ErrLostLootFull = errors.New("lost loot machine is full")
+)
+
+// Bank is the decoded content of a profile's bank and lost loot machine.
+// Items of unknown categories are kept with their wrapped serial number and written back unchanged.
+type Bank struct {
+	Items        []item.Item `json:"items"`
+	LostLoot     []item.Item `json:"lostLoot"`
+	Capacity     int         `json:"capacity"`
+	LostLootSize int         `json:"lostLootSize"`
+}
+
+/*
+GetBank decodes the bank and lost loot contents of the profile.
+Returns an error if an item can't be decoded, items of unknown categories are kept as they are.
+This requires a valid database to be set.
+*/
+func GetBank(p *pb.Profile) (Bank, error) {
+	items, err := decodeSerials(p.BankInventoryList)
+	if err != nil {
+		return Bank{}, fmt.Errorf("bank: %v", err)
+	}
+	lostLoot, err := decodeSerials(p.LostLootInventoryList)
+	if err != nil {
+		return Bank{}, fmt.Errorf("lost loot: %v", err)
+	}
+	return Bank{
+		Items:        items,
+		LostLoot:     lostLoot,
+		Capacity:     BankSize(p),
+		LostLootSize: LostLootSize(p),
+	}, nil
+}
+
+// BankSize returns the number of bank slots granted by the profile's SDUs, or -1 if it isn't known.
+func BankSize(p *pb.Profile) int {
+	sdu, _ := assets.FindSDU(assets.SDUBank)
+	return sdu.Capacity(p.ProfileSduList)
+}
+
+// LostLootSize returns the number of lost loot slots granted by the profile's SDUs, or -1 if it isn't known.
+func LostLootSize(p *pb.Profile) int {
+	sdu, _ := assets.FindSDU(assets.SDULostLoot)
+	return sdu.Capacity(p.ProfileSduList)
+}
+
+/*
+SetSDU sets the level of a profile SDU, i.e. the bank or lost loot SDU, given by its name or path.
+*/
+func SetSDU(p *pb.Profile, name string, level int) error {
+	sdu, ok := assets.FindSDU(name)
+	if !ok {
+		return fmt.Errorf("unknown SDU %s", name)
+	}
+	if !sdu.Profile {
+		return fmt.Errorf("SDU %s is stored per character", sdu.Name)
+	}
+	list, err := sdu.SetLevel(p.ProfileSduList, level)
+	if err != nil {
+		return err
+	}
+	p.ProfileSduList = list
+	return nil
+}
+
+/*
+MaxSDUs sets all profile SDUs to their highest level.
+Returns an error without changing the profile if the highest level of an SDU isn't known.
+*/
+func MaxSDUs(p *pb.Profile) error {
+	if err := assets.CheckSDUMaxLevels(true); err != nil {
+		return err
+	}
+	for _, sdu := range assets.SDUs {
+		if sdu.Profile {
+			p.ProfileSduList, _ = sdu.SetLevel(p.ProfileSduList, sdu.MaxLevel)
+		}
+	}
+	return nil
+}
+
+/*
+Apply serializes the bank and lost loot contents back into the profile.
+This requires a valid database to be set.
+*/
+func (b Bank) Apply(p *pb.Profile) error {
+	bank, err := encodeItems(b.Items)
+	if err != nil {
+		return err
+	}
+	lostLoot, err := encodeItems(b.LostLoot)
+	if err != nil {
+		return err
+	}
+	p.BankInventoryList = bank
+	p.LostLootInventoryList = lostLoot
+	return nil
+}
+
+/*
+Add adds an item to the end of the bank. Returns ErrBankFull if the bank has no free slots.
+A negative capacity means the size isn't known and isn't checked.
+*/
+func (b *Bank) Add(i item.Item) error {
+	if b.Capacity >= 0 && len(b.Items) >= b.Capacity {
+		return ErrBankFull
+	}
+	b.Items = append(b.Items, i)
+	return nil
+}
+
+/*
+AddLostLoot adds an item to the end of the lost loot machine. Returns ErrLostLootFull if the machine has no free slots.
+A negative size means the size isn't known and isn't checked.
+*/
+func (b *Bank) AddLostLoot(i item.Item) error {
+	if b.LostLootSize >= 0 && len(b.LostLoot) >= b.LostLootSize {
+		return ErrLostLootFull
+	}
+	b.LostLoot = append(b.LostLoot, i)
+	return nil
+}
+
+// Remove removes the bank item at the given index.
+func (b *Bank) Remove(index int) (item.Item, error) {
+	if index < 0 || index >= len(b.Items) {
+		return item.Item{}, fmt.Errorf("invalid bank index %d", index)
+	}
+	i := b.Items[index]
+	b.Items = append(b.Items[:index], b.Items[index+1:]...)
+	return i, nil
+}
+
+/*
+ClaimLostLoot moves the lost loot item at the given index into the bank.
+*/
+func (b *Bank) ClaimLostLoot(index int) error {
+	if index < 0 || index >= len(b.LostLoot) {
+		return fmt.Errorf("invalid lost loot index %d", index)
+	}
+	if err := b.Add(b.LostLoot[index]); err != nil {
+		return err
+	}
+	b.LostLoot = append(b.LostLoot[:index], b.LostLoot[index+1:]...)
+	return nil
+}
+
+// Filter returns the indexes of all bank items matching the given predicate.
+func (b Bank) Filter(match func(item.Item) bool) []int {
+	indexes := make([]int, 0)
+	for index, i := range b.Items {
+		if match(i) {
+			indexes = append(indexes, index)
+		}
+	}
+	return indexes
+}
+
+/*
+Sort sorts the bank by category, then by rarity and level, highest first.
+Items of equal rank keep their relative order.
+*/
+func (b *Bank) Sort() {
+	sort.SliceStable(b.Items, func(x, y int) bool {
+		a, c := b.Items[x], b.Items[y]
+		if ca, cc := item.GetCategory(a), item.GetCategory(c); ca != cc {
+			return ca < cc
+		}
+		if ra, rc := item.GetRarity(a), item.GetRarity(c); ra != rc {
+			return ra > rc
+		}
+		return a.Level > c.Level
+	})
+}
+
+/*
+Deduplicate removes bank items whose decoded content equals an earlier item's and returns the number of removed items.
+*/
+func (b *Bank) Deduplicate() int {
+	unique := make([]item.Item, 0, len(b.Items))
+	for _, i := range b.Items {
+		duplicate := false
+		for _, u := range unique {
+			if item.Equal(i, u) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			unique = append(unique, i)
+		}
+	}
+	removed := len(b.Items) - len(unique)
+	b.Items = unique
+	return removed
+}
+
+func decodeSerials(list [][]byte) ([]item.Item, error) {
+	items := make([]item.Item, 0, len(list))
+	for index, data := range list {
+		i, err := item.Decode(data)
+		// items of unknown categories are written back unchanged, see item.Serialize
+		if err != nil && !i.SkipIntrospection {
+			return nil, fmt.Errorf("item %d: %v", index, err)
+		}
+		i.Wrapper = &pb.OakInventoryItemSaveGameData{
+			ItemSerialNumber: data,
+		}
+		items = append(items, i)
+	}
+	return items, nil
+}
+
+func encodeItems(items []item.Item) ([][]byte, error) {
+	list := make([][]byte, len(items))
+	for index, i := range items {
+		serial, err := item.Reserialize(i)
+		if err != nil {
+			return nil, err
+		}
+		list[index] = serial
+	}
+	return list, nil
+}
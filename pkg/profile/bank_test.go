@@ -0,0 +1,66 @@
+package profile
+
+import (
+	"testing"
+
+	"github.com/cfi2017/bl3-save-core/internal/testassets"
+	"github.com/cfi2017/bl3-save-core/pkg/item"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+func TestBankSortAndDeduplicate(t *testing.T) {
+	pistol := item.Item{
+		Level:   50,
+		Balance: "/Game/Gear/Weapons/Pistols/Jakobs/_Shared/_Design/_Manufacturer/Balance/Balance_PS_JAK_04_VeryRare.Balance_PS_JAK_04_VeryRare",
+		InvData: "/Game/Gear/Weapons/Pistols/Jakobs/_Shared/_Design/A_Data/PS_JAK.PS_JAK",
+	}
+	legendary := pistol
+	legendary.Balance = "/Game/Gear/Weapons/Pistols/Jakobs/_Shared/_Design/_Manufacturer/Balance/Balance_PS_JAK_05_Legendary.Balance_PS_JAK_05_Legendary"
+	shield := item.Item{
+		Level:   57,
+		Balance: "/Game/Gear/Shields/_Design/InvBalance/InvBalD_Shield_Anshin_01_Common.InvBalD_Shield_Anshin_01_Common",
+		InvData: "/Game/Gear/Shields/_Design/A_Data/Shield_Default.Shield_Default",
+	}
+	b := Bank{Items: []item.Item{shield, pistol, legendary, pistol}, Capacity: 4}
+	if err := b.Add(pistol); err != ErrBankFull {
+		t.Fatal("expected full bank")
+	}
+	if removed := b.Deduplicate(); removed != 1 {
+		t.Fatalf("expected 1 duplicate, removed %d", removed)
+	}
+	b.Sort()
+	if b.Items[0].Balance != legendary.Balance || b.Items[1].Balance != pistol.Balance || b.Items[2].Balance != shield.Balance {
+		t.Fatalf("unexpected sort order %v", b.Items)
+	}
+	if legendaries := b.Filter(func(i item.Item) bool { return item.GetRarity(i) == item.RarityLegendary }); len(legendaries) != 1 {
+		t.Fatal("filter didn't match legendary")
+	}
+}
+
+func TestGetBank(t *testing.T) {
+	defer testassets.Load()()
+	unknown := testassets.Item(30)
+	unknown.Balance = testassets.UnknownBalance
+	p := &pb.Profile{
+		BankInventoryList:     [][]byte{testassets.Serial(testassets.Item(50), 1), testassets.Serial(unknown, 2)},
+		LostLootInventoryList: [][]byte{testassets.Serial(testassets.Item(60), 3)},
+	}
+	b, err := GetBank(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b.Items) != 2 || b.Items[0].Level != 50 || !b.Items[1].SkipIntrospection || b.LostLoot[0].Level != 60 {
+		t.Fatalf("unexpected bank %v", b)
+	}
+	if err := b.Apply(p); err != nil {
+		t.Fatal(err)
+	}
+	if b, err = GetBank(p); err != nil || b.Items[1].Balance != testassets.UnknownBalance {
+		t.Fatalf("item of unknown category changed: %v", err)
+	}
+
+	p.BankInventoryList = append(p.BankInventoryList, []byte{4, 0, 0, 0, 1, 2, 3})
+	if _, err := GetBank(p); err == nil {
+		t.Fatal("expected error for malformed serial")
+	}
+}
@@ -0,0 +1,297 @@
+package profile
+
+import (
+	"bufio"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+// Binding is a single rebound action of a binding category.
+// Categories and actions are identified by the asset name of their data path, e.g. Rebind_Jump.
+type Binding struct {
+	Category string    `json:"category"`
+	Action   string    `json:"action"`
+	Axis     bool      `json:"axis"`
+	Keys     []AxisKey `json:"keys"`
+}
+
+// AxisKey is a key bound to an action. Scale is only used by axis bindings.
+type AxisKey struct {
+	Key   string     `json:"key"`
+	Scale [3]float32 `json:"scale"`
+}
+
+// Conflict is a key that is bound to more than one action in the same category.
+type Conflict struct {
+	Category string   `json:"category"`
+	Key      string   `json:"key"`
+	Actions  []string `json:"actions"`
+}
+
+/*
+ListBindings returns all rebound actions of the given category, or of all categories if category is empty.
+Actions that aren't listed use the game's default binding.
+*/
+func ListBindings(p *pb.Profile, category string) []Binding {
+	bindings := make([]Binding, 0)
+	for _, c := range p.GetPlayerInputBindings().GetCategories() {
+		if category != "" && !matchesAsset(c.CategoryDataPath, category) {
+			continue
+		}
+		name := assetName(c.CategoryDataPath)
+		for _, b := range c.ButtonBindings {
+			keys := make([]AxisKey, len(b.KeyNames))
+			for i, k := range b.KeyNames {
+				keys[i] = AxisKey{Key: k}
+			}
+			bindings = append(bindings, Binding{Category: name, Action: assetName(b.RebindDataPath), Keys: keys})
+		}
+		for _, a := range c.AxisBindings {
+			keys := make([]AxisKey, len(a.Keys))
+			for i, k := range a.Keys {
+				keys[i] = AxisKey{Key: k.KeyName}
+				if k.Scale_3D != nil {
+					keys[i].Scale = [3]float32{k.Scale_3D.X, k.Scale_3D.Y, k.Scale_3D.Z}
+				}
+			}
+			bindings = append(bindings, Binding{Category: name, Action: assetName(a.RebindDataPath), Axis: true, Keys: keys})
+		}
+	}
+	return bindings
+}
+
+/*
+Rebind binds a button action to the given keys, replacing its previous keys.
+Categories and actions are matched by asset name or data path; unknown ones are only created if given as a full data path.
+*/
+func Rebind(p *pb.Profile, category, action string, keys ...string) error {
+	c, err := getBindingCategory(p, category)
+	if err != nil {
+		return err
+	}
+	for _, b := range c.ButtonBindings {
+		if matchesAsset(b.RebindDataPath, action) {
+			b.KeyNames = keys
+			return nil
+		}
+	}
+	if !strings.HasPrefix(action, "/") {
+		return fmt.Errorf("unknown action %s in category %s, use its data path to add it", action, assetName(c.CategoryDataPath))
+	}
+	c.ButtonBindings = append(c.ButtonBindings, &pb.PlayerInputBinding_Button{RebindDataPath: action, KeyNames: keys})
+	return nil
+}
+
+/*
+RebindAxis binds an axis action to the given keys and scales, replacing its previous keys.
+Categories and actions are matched like in Rebind.
+*/
+func RebindAxis(p *pb.Profile, category, action string, keys ...AxisKey) error {
+	c, err := getBindingCategory(p, category)
+	if err != nil {
+		return err
+	}
+	axisKeys := make([]*pb.PlayerInputBinding_Axis_Key, len(keys))
+	for i, k := range keys {
+		axisKeys[i] = &pb.PlayerInputBinding_Axis_Key{
+			KeyName:  k.Key,
+			Scale_3D: &pb.Vec3{X: k.Scale[0], Y: k.Scale[1], Z: k.Scale[2]},
+		}
+	}
+	for _, a := range c.AxisBindings {
+		if matchesAsset(a.RebindDataPath, action) {
+			a.Keys = axisKeys
+			return nil
+		}
+	}
+	if !strings.HasPrefix(action, "/") {
+		return fmt.Errorf("unknown axis %s in category %s, use its data path to add it", action, assetName(c.CategoryDataPath))
+	}
+	c.AxisBindings = append(c.AxisBindings, &pb.PlayerInputBinding_Axis{RebindDataPath: action, Keys: axisKeys})
+	return nil
+}
+
+/*
+FindConflicts returns all keys that are bound to more than one button action within the same category.
+*/
+func FindConflicts(p *pb.Profile) []Conflict {
+	conflicts := make([]Conflict, 0)
+	for _, c := range p.GetPlayerInputBindings().GetCategories() {
+		actions := make(map[string][]string)
+		for _, b := range c.ButtonBindings {
+			for _, k := range b.KeyNames {
+				actions[k] = append(actions[k], assetName(b.RebindDataPath))
+			}
+		}
+		keys := make([]string, 0, len(actions))
+		for k := range actions {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if len(actions[k]) > 1 {
+				conflicts = append(conflicts, Conflict{Category: assetName(c.CategoryDataPath), Key: k, Actions: actions[k]})
+			}
+		}
+	}
+	return conflicts
+}
+
+/*
+ResetCategory resets all actions of a category to the game's defaults by removing the category's rebinds.
+*/
+func ResetCategory(p *pb.Profile, category string) error {
+	if p.PlayerInputBindings != nil {
+		for i, c := range p.PlayerInputBindings.Categories {
+			if matchesAsset(c.CategoryDataPath, category) {
+				p.PlayerInputBindings.Categories = append(p.PlayerInputBindings.Categories[:i], p.PlayerInputBindings.Categories[i+1:]...)
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("no rebinds in category %s", category)
+}
+
+/*
+FormatBindings writes input bindings in a line based text format:
+
+	category <category data path> <context data path>
+	button <rebind data path> = <key> <key> ...
+	axis <rebind data path> = <key>(<x>,<y>,<z>) ...
+
+Empty lines and lines starting with # are ignored by ParseBindings.
+*/
+func FormatBindings(b *pb.PlayerInputBindings) string {
+	sb := &strings.Builder{}
+	for _, c := range b.GetCategories() {
+		fmt.Fprintf(sb, "category %s %s\n", c.CategoryDataPath, c.ContextDataPath)
+		for _, button := range c.ButtonBindings {
+			fmt.Fprintf(sb, "button %s = %s\n", button.RebindDataPath, strings.Join(button.KeyNames, " "))
+		}
+		for _, axis := range c.AxisBindings {
+			keys := make([]string, len(axis.Keys))
+			for i, k := range axis.Keys {
+				v := k.Scale_3D
+				if v == nil {
+					v = &pb.Vec3{}
+				}
+				keys[i] = fmt.Sprintf("%s(%s,%s,%s)", k.KeyName, formatFloat(v.X), formatFloat(v.Y), formatFloat(v.Z))
+			}
+			fmt.Fprintf(sb, "axis %s = %s\n", axis.RebindDataPath, strings.Join(keys, " "))
+		}
+	}
+	return sb.String()
+}
+
+/*
+ParseBindings parses input bindings written by FormatBindings.
+*/
+func ParseBindings(text string) (*pb.PlayerInputBindings, error) {
+	b := &pb.PlayerInputBindings{}
+	var category *pb.PlayerInputBinding_Category
+	s := bufio.NewScanner(strings.NewReader(text))
+	for line := 1; s.Scan(); line++ {
+		fields := strings.Fields(s.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if fields[0] == "category" {
+			if len(fields) < 2 || len(fields) > 3 {
+				return nil, fmt.Errorf("line %d: expected category <path> [context]", line)
+			}
+			category = &pb.PlayerInputBinding_Category{CategoryDataPath: fields[1]}
+			if len(fields) == 3 {
+				category.ContextDataPath = fields[2]
+			}
+			b.Categories = append(b.Categories, category)
+			continue
+		}
+		if category == nil {
+			return nil, fmt.Errorf("line %d: binding outside of category", line)
+		}
+		if len(fields) < 3 || fields[2] != "=" {
+			return nil, fmt.Errorf("line %d: expected %s <path> = <keys>", line, fields[0])
+		}
+		switch fields[0] {
+		case "button":
+			category.ButtonBindings = append(category.ButtonBindings, &pb.PlayerInputBinding_Button{
+				RebindDataPath: fields[1],
+				KeyNames:       fields[3:],
+			})
+		case "axis":
+			axis := &pb.PlayerInputBinding_Axis{RebindDataPath: fields[1]}
+			for _, k := range fields[3:] {
+				key, err := parseAxisKey(k)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %v", line, err)
+				}
+				axis.Keys = append(axis.Keys, key)
+			}
+			category.AxisBindings = append(category.AxisBindings, axis)
+		default:
+			return nil, fmt.Errorf("line %d: unknown binding type %s", line, fields[0])
+		}
+	}
+	return b, s.Err()
+}
+
+func parseAxisKey(s string) (*pb.PlayerInputBinding_Axis_Key, error) {
+	open := strings.Index(s, "(")
+	if open < 1 || !strings.HasSuffix(s, ")") {
+		return nil, fmt.Errorf("invalid axis key %s, expected key(x,y,z)", s)
+	}
+	parts := strings.Split(s[open+1:len(s)-1], ",")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid axis key %s, expected key(x,y,z)", s)
+	}
+	var scale [3]float32
+	for i, part := range parts {
+		f, err := strconv.ParseFloat(part, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid axis key %s: %v", s, err)
+		}
+		scale[i] = float32(f)
+	}
+	return &pb.PlayerInputBinding_Axis_Key{
+		KeyName:  s[:open],
+		Scale_3D: &pb.Vec3{X: scale[0], Y: scale[1], Z: scale[2]},
+	}, nil
+}
+
+func formatFloat(f float32) string {
+	return strconv.FormatFloat(float64(f), 'g', -1, 32)
+}
+
+func getBindingCategory(p *pb.Profile, category string) (*pb.PlayerInputBinding_Category, error) {
+	if p.PlayerInputBindings == nil {
+		p.PlayerInputBindings = &pb.PlayerInputBindings{}
+	}
+	for _, c := range p.PlayerInputBindings.Categories {
+		if matchesAsset(c.CategoryDataPath, category) {
+			return c, nil
+		}
+	}
+	if !strings.HasPrefix(category, "/") {
+		return nil, fmt.Errorf("unknown binding category %s, use its data path to add it", category)
+	}
+	c := &pb.PlayerInputBinding_Category{CategoryDataPath: category}
+	p.PlayerInputBindings.Categories = append(p.PlayerInputBindings.Categories, c)
+	return c, nil
+}
+
+// assetName returns the asset name of a data path, e.g. Rebind_Jump for /Game/Input/Rebind_Jump.Rebind_Jump.
+func assetName(path string) string {
+	name := path[strings.LastIndex(path, "/")+1:]
+	if i := strings.Index(name, "."); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+func matchesAsset(path, name string) bool {
+	return strings.EqualFold(path, name) || strings.EqualFold(assetName(path), name)
+}
@@ -0,0 +1,51 @@
+package profile
+
+import (
+	"testing"
+
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+func TestBindings(t *testing.T) {
+	p := &pb.Profile{}
+	category := "/Game/Input/Categories/Category_OnFoot.Category_OnFoot"
+	if err := Rebind(p, category, "/Game/Input/Rebind/Rebind_Jump.Rebind_Jump", "SpaceBar"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Rebind(p, "Category_OnFoot", "/Game/Input/Rebind/Rebind_Crouch.Rebind_Crouch", "SpaceBar", "C"); err != nil {
+		t.Fatal(err)
+	}
+	if err := RebindAxis(p, "Category_OnFoot", "/Game/Input/Rebind/Rebind_MoveForward.Rebind_MoveForward",
+		AxisKey{Key: "W", Scale: [3]float32{1, 0, 0}}, AxisKey{Key: "S", Scale: [3]float32{-1, 0, 0}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Rebind(p, "Category_OnFoot", "Rebind_Unknown", "X"); err == nil {
+		t.Fatal("expected error for unknown action")
+	}
+	conflicts := FindConflicts(p)
+	if len(conflicts) != 1 || conflicts[0].Key != "SpaceBar" || len(conflicts[0].Actions) != 2 {
+		t.Fatalf("unexpected conflicts %v", conflicts)
+	}
+	if err := Rebind(p, "Category_OnFoot", "rebind_crouch", "C"); err != nil {
+		t.Fatal(err)
+	}
+	if len(FindConflicts(p)) != 0 {
+		t.Fatal("conflict not resolved")
+	}
+	if bindings := ListBindings(p, "Category_OnFoot"); len(bindings) != 3 || !bindings[2].Axis {
+		t.Fatalf("unexpected bindings %v", bindings)
+	}
+
+	text := FormatBindings(p.PlayerInputBindings)
+	parsed, err := ParseBindings(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if FormatBindings(parsed) != text {
+		t.Fatalf("bindings changed in round trip:\n%s\n%s", text, FormatBindings(parsed))
+	}
+
+	if err := ResetCategory(p, "Category_OnFoot"); err != nil || len(ListBindings(p, "")) != 0 {
+		t.Fatal("category not reset")
+	}
+}
@@ -0,0 +1,83 @@
+package profile
+
+import (
+	"fmt"
+
+	"github.com/cfi2017/bl3-save-core/pkg/assets"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+/*
+UnlockAllCosmetics unlocks every cosmetic registered in assets.Cosmetics on the profile.
+Heads, skins, emotes and ECHO themes are unlocked by path, weapon trinkets and skins by part hash.
+Returns an error if no cosmetics besides the defaults are registered or a weapon trinket or skin has no hash.
+*/
+func UnlockAllCosmetics(p *pb.Profile) error {
+	unlockable := make([]assets.Cosmetic, 0)
+	for _, c := range assets.Cosmetics {
+		if c.IsInventoryPart() && c.Hash == 0 {
+			return fmt.Errorf("hash of cosmetic %s not known", c.Name)
+		}
+		if !c.Default {
+			unlockable = append(unlockable, c)
+		}
+	}
+	if len(unlockable) == 0 {
+		return fmt.Errorf("no cosmetics known besides the defaults, they're read from the catalog")
+	}
+	for _, c := range unlockable {
+		if err := UnlockCosmetic(p, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+UnlockCosmetic unlocks a single cosmetic on the profile. Already unlocked cosmetics are left untouched.
+Weapon trinkets and skins are unlocked by their hash, which must be known.
+*/
+func UnlockCosmetic(p *pb.Profile, c assets.Cosmetic) error {
+	if IsCosmeticUnlocked(p, c) {
+		return nil
+	}
+	if c.IsInventoryPart() {
+		if c.Hash == 0 {
+			return fmt.Errorf("hash of cosmetic %s not known", c.Name)
+		}
+		p.UnlockedInventoryCustomizationParts = append(p.UnlockedInventoryCustomizationParts, &pb.OakInventoryCustomizationPartInfo{
+			CustomizationPartHash: c.Hash,
+			IsNew:                 true,
+		})
+		return nil
+	}
+	p.UnlockedCustomizations = append(p.UnlockedCustomizations, &pb.OakCustomizationSaveGameData{
+		IsNew:                  true,
+		CustomizationAssetPath: c.Path,
+	})
+	return nil
+}
+
+/*
+IsCosmeticUnlocked reports whether the cosmetic is available on the profile.
+Default cosmetics are always available.
+*/
+func IsCosmeticUnlocked(p *pb.Profile, c assets.Cosmetic) bool {
+	if c.Default {
+		return true
+	}
+	if c.IsInventoryPart() {
+		for _, part := range p.UnlockedInventoryCustomizationParts {
+			if c.Hash != 0 && part.CustomizationPartHash == c.Hash {
+				return true
+			}
+		}
+		return false
+	}
+	for _, u := range p.UnlockedCustomizations {
+		if u.CustomizationAssetPath == c.Path {
+			return true
+		}
+	}
+	return false
+}
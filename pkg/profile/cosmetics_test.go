@@ -0,0 +1,55 @@
+package profile
+
+import (
+	"testing"
+
+	"github.com/cfi2017/bl3-save-core/internal/testassets"
+	"github.com/cfi2017/bl3-save-core/pkg/assets"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+func TestUnlockCosmetic(t *testing.T) {
+	p := &pb.Profile{}
+	skin, ok := assets.FindCosmetic("/Game/PlayerCharacters/_Customizations/Operative/Skins/CustomSkin_Operative_7.CustomSkin_Operative_7")
+	if !ok || skin.Kind != assets.CosmeticSkin || skin.Class != "Operative" {
+		t.Fatalf("skin not recognized: %v", skin)
+	}
+	if IsCosmeticUnlocked(p, skin) {
+		t.Fatal("skin unlocked on an empty profile")
+	}
+	for i := 0; i < 2; i++ {
+		if err := UnlockCosmetic(p, skin); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !IsCosmeticUnlocked(p, skin) || len(p.UnlockedCustomizations) != 1 || !p.UnlockedCustomizations[0].IsNew {
+		t.Fatalf("unexpected unlocks %v", p.UnlockedCustomizations)
+	}
+	if head, _ := assets.FindCosmetic("Zane default head"); !IsCosmeticUnlocked(p, head) {
+		t.Fatal("default cosmetics must always be unlocked")
+	}
+	if _, ok := assets.FindCosmetic("/Game/PlayerCharacters/_Customizations/Operative/Emotes/CustomEmote_Operative_01.CustomEmote_Operative_01"); ok {
+		t.Fatal("emotes are not part of the catalog")
+	}
+}
+
+func TestUnlockAllCosmetics(t *testing.T) {
+	p := &pb.Profile{}
+	if err := UnlockAllCosmetics(p); err == nil {
+		t.Fatal("expected error without known cosmetics")
+	}
+	defer testassets.Catalog()()
+	for i := 0; i < 2; i++ {
+		if err := UnlockAllCosmetics(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(p.UnlockedCustomizations) != 1 || len(p.UnlockedInventoryCustomizationParts) != 1 ||
+		p.UnlockedInventoryCustomizationParts[0].CustomizationPartHash != testassets.TrinketHash {
+		t.Fatalf("unexpected unlocks %v %v", p.UnlockedCustomizations, p.UnlockedInventoryCustomizationParts)
+	}
+	trinket, _ := assets.FindCosmeticByHash(testassets.TrinketHash)
+	if !IsCosmeticUnlocked(p, trinket) {
+		t.Fatal("weapon trinket not unlocked")
+	}
+}
@@ -0,0 +1,66 @@
+package profile
+
+import (
+	"fmt"
+
+	"github.com/cfi2017/bl3-save-core/pkg/assets"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+/*
+UnlockCrewQuartersRooms unlocks the given crew quarters rooms, by friendly name or path, on the profile.
+*/
+func UnlockCrewQuartersRooms(p *pb.Profile, names ...string) error {
+	for _, name := range names {
+		r, ok := assets.FindCrewQuartersRoom(name)
+		if !ok {
+			return fmt.Errorf("unknown crew quarters room: %s", name)
+		}
+		if !IsCrewQuartersRoomUnlocked(p, r.Path) {
+			p.UnlockedCrewQuartersRooms = append(p.UnlockedCrewQuartersRooms, &pb.CrewQuartersRoomItemSaveGameData{
+				IsNew:             true,
+				RoomItemAssetPath: r.Path,
+			})
+		}
+	}
+	return nil
+}
+
+/*
+UnlockCrewQuartersDecorations unlocks the given crew quarters decorations, by friendly name or path, on the profile.
+*/
+func UnlockCrewQuartersDecorations(p *pb.Profile, names ...string) error {
+	for _, name := range names {
+		d, ok := assets.FindCrewQuartersDecoration(name)
+		if !ok {
+			return fmt.Errorf("unknown crew quarters decoration: %s", name)
+		}
+		if !IsCrewQuartersDecorationUnlocked(p, d.Path) {
+			p.UnlockedCrewQuartersDecorations = append(p.UnlockedCrewQuartersDecorations, &pb.CrewQuartersDecorationItemSaveGameData{
+				IsNew:                   true,
+				DecorationItemAssetPath: d.Path,
+			})
+		}
+	}
+	return nil
+}
+
+// IsCrewQuartersRoomUnlocked reports whether the room with the given path is unlocked on the profile.
+func IsCrewQuartersRoomUnlocked(p *pb.Profile, path string) bool {
+	for _, r := range p.UnlockedCrewQuartersRooms {
+		if r.RoomItemAssetPath == path {
+			return true
+		}
+	}
+	return false
+}
+
+// IsCrewQuartersDecorationUnlocked reports whether the decoration with the given path is unlocked on the profile.
+func IsCrewQuartersDecorationUnlocked(p *pb.Profile, path string) bool {
+	for _, d := range p.UnlockedCrewQuartersDecorations {
+		if d.DecorationItemAssetPath == path {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,14 @@
+package profile
+
+import (
+	"github.com/cfi2017/bl3-save-core/pkg/diff"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+/*
+Diff returns the differences between two profiles, e.g. a backup and the current save.
+Bank and lost loot items are compared by their decoded content, see diff.Messages.
+*/
+func Diff(a, b *pb.Profile) []diff.Change {
+	return diff.Messages(a, b)
+}
@@ -0,0 +1,43 @@
+package profile
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cfi2017/bl3-save-core/internal/testassets"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+func TestDiff(t *testing.T) {
+	defer testassets.Load()()
+	overflow := testassets.Item(10)
+	overflow.Overflow = "11111111"
+	a := &pb.Profile{
+		BankInventoryList: [][]byte{
+			testassets.Serial(testassets.Item(10), 1),
+			testassets.Serial(testassets.Item(20), 1),
+		},
+		LostLootInventoryList: [][]byte{testassets.Serial(testassets.Item(10), 1)},
+	}
+	b := &pb.Profile{
+		BankInventoryList: [][]byte{
+			// moved and re-seeded, the decoded content is unchanged
+			testassets.Serial(testassets.Item(20), 3),
+			testassets.Serial(testassets.Item(10), 4),
+		},
+		LostLootInventoryList: [][]byte{testassets.Serial(overflow, 1)},
+		LastUsedSavegameId:    2,
+	}
+	var got []string
+	for _, c := range Diff(a, b) {
+		got = append(got, c.String())
+	}
+	want := []string{
+		"LastUsedSavegameId: 0 -> 2",
+		"LostLootInventoryList[0]: Balance_AR_VLA_05_Legendary level 10 -> (none)",
+		"LostLootInventoryList[0]: (none) -> Balance_AR_VLA_05_Legendary level 10",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected changes:\n%s", strings.Join(got, "\n"))
+	}
+}
@@ -0,0 +1,150 @@
+package profile
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cfi2017/bl3-save-core/pkg/assets"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+// GuardianRank is the account wide guardian rank shared by a profile and its characters.
+// Every rank grants one token, Rewards holds the number of tokens spent per reward path.
+type GuardianRank struct {
+	Rank       int            `json:"rank"`
+	Experience int64          `json:"experience"`
+	Rewards    map[string]int `json:"rewards"`
+}
+
+/*
+GetGuardianRank reads the guardian rank from the profile.
+*/
+func GetGuardianRank(p *pb.Profile) GuardianRank {
+	g := GuardianRank{Rewards: make(map[string]int)}
+	if p.GuardianRank == nil {
+		return g
+	}
+	g.Rank = int(p.GuardianRank.GuardianRank)
+	g.Experience = p.GuardianRank.NewGuardianExperience
+	if g.Experience == 0 {
+		g.Experience = int64(p.GuardianRank.GuardianExperience)
+	}
+	for _, r := range p.GuardianRank.RankRewards {
+		g.Rewards[r.RewardDataPath] = int(r.NumTokens)
+	}
+	return g
+}
+
+/*
+SetReward sets the number of tokens spent on a reward, given by its friendly name or path.
+*/
+func (g *GuardianRank) SetReward(name string, tokens int) error {
+	r, ok := assets.FindGuardianReward(name)
+	if !ok {
+		return fmt.Errorf("unknown guardian reward: %s", name)
+	}
+	if tokens < 0 {
+		return fmt.Errorf("invalid token count %d for guardian reward %s", tokens, r.Name)
+	}
+	if g.Rewards == nil {
+		g.Rewards = make(map[string]int)
+	}
+	g.Rewards[r.Path] = tokens
+	return nil
+}
+
+// SpentTokens returns the number of tokens spent on rewards.
+func (g GuardianRank) SpentTokens() int {
+	spent := 0
+	for _, tokens := range g.Rewards {
+		spent += tokens
+	}
+	return spent
+}
+
+// AvailableTokens returns the number of tokens that can still be spent.
+func (g GuardianRank) AvailableTokens() int {
+	return g.Rank - g.SpentTokens()
+}
+
+/*
+Apply writes the guardian rank to the profile and all given characters.
+Returns an error without modifying anything if more tokens are spent than the rank grants.
+*/
+func (g GuardianRank) Apply(p *pb.Profile, characters ...*pb.Character) error {
+	if g.Rank < 0 {
+		return fmt.Errorf("invalid guardian rank %d", g.Rank)
+	}
+	if g.AvailableTokens() < 0 {
+		return fmt.Errorf("guardian rank %d grants %d tokens, %d spent", g.Rank, g.Rank, g.SpentTokens())
+	}
+	paths := make([]string, 0, len(g.Rewards))
+	for path := range g.Rewards {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	if p != nil {
+		if p.GuardianRank == nil {
+			p.GuardianRank = &pb.GuardianRankProfileData{}
+		}
+		data := p.GuardianRank
+		data.GuardianRank = int32(g.Rank)
+		data.GuardianExperience = int32(g.Experience)
+		data.NewGuardianExperience = g.Experience
+		data.AvailableTokens = int32(g.AvailableTokens())
+		for _, path := range paths {
+			reward := findProfileReward(data, path)
+			if reward == nil {
+				reward = &pb.GuardianRankRewardSaveGameData{RewardDataPath: path}
+				data.RankRewards = append(data.RankRewards, reward)
+			}
+			reward.NumTokens = int32(g.Rewards[path])
+		}
+	}
+
+	for _, c := range characters {
+		if c.GuardianRank == nil {
+			c.GuardianRank = &pb.GuardianRankSaveGameData{}
+		}
+		c.GuardianRank.GuardianRank = int32(g.Rank)
+		c.GuardianRank.GuardianExperience = int32(g.Experience)
+
+		if c.GuardianRankCharacterData == nil {
+			c.GuardianRankCharacterData = &pb.GuardianRankCharacterSaveGameData{}
+		}
+		data := c.GuardianRankCharacterData
+		data.GuardianRank = int32(g.Rank)
+		data.GuardianExperience = int32(g.Experience)
+		data.NewGuardianExperience = g.Experience
+		data.GuardianAvailableTokens = int32(g.AvailableTokens())
+		data.IsRankSystemEnabled = data.IsRankSystemEnabled || g.Rank > 0
+		for _, path := range paths {
+			reward := findCharacterReward(data, path)
+			if reward == nil {
+				reward = &pb.GuardianRankRewardCharacterSaveGameData{RewardDataPath: path, IsEnabled: true}
+				data.RankRewards = append(data.RankRewards, reward)
+			}
+			reward.NumTokens = int32(g.Rewards[path])
+		}
+	}
+	return nil
+}
+
+func findProfileReward(data *pb.GuardianRankProfileData, path string) *pb.GuardianRankRewardSaveGameData {
+	for _, r := range data.RankRewards {
+		if r.RewardDataPath == path {
+			return r
+		}
+	}
+	return nil
+}
+
+func findCharacterReward(data *pb.GuardianRankCharacterSaveGameData, path string) *pb.GuardianRankRewardCharacterSaveGameData {
+	for _, r := range data.RankRewards {
+		if r.RewardDataPath == path {
+			return r
+		}
+	}
+	return nil
+}
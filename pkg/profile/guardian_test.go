@@ -0,0 +1,42 @@
+package profile
+
+import (
+	"testing"
+
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+func TestGuardianRankApply(t *testing.T) {
+	p := &pb.Profile{}
+	c := &pb.Character{}
+	g := GetGuardianRank(p)
+	g.Rank = 10
+	if err := g.SetReward("Gun Damage", 4); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.SetReward("Max Health", 3); err != nil {
+		t.Fatal(err)
+	}
+	if g.AvailableTokens() != 3 {
+		t.Fatalf("invalid available tokens %d", g.AvailableTokens())
+	}
+	if err := g.Apply(p, c); err != nil {
+		t.Fatal(err)
+	}
+	if p.GuardianRank.AvailableTokens != 3 || c.GuardianRankCharacterData.GuardianAvailableTokens != 3 {
+		t.Fatal("available tokens not propagated")
+	}
+	if len(p.GuardianRank.RankRewards) != 2 || len(c.GuardianRankCharacterData.RankRewards) != 2 {
+		t.Fatal("rewards not propagated")
+	}
+	if c.GuardianRank.GuardianRank != 10 || GetGuardianRank(p).Rank != 10 {
+		t.Fatal("rank not propagated")
+	}
+
+	if err := g.SetReward("Gun Damage", 8); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Apply(p, c); err == nil {
+		t.Fatal("expected error for overspent tokens")
+	}
+}
@@ -0,0 +1,192 @@
+package profile
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cfi2017/bl3-save-core/pkg/item"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+const (
+	// MailTypeGear is the mail item type of mail with a gear attachment.
+	MailTypeGear = 1
+	// DefaultMailSender is used as sender display name if none is given.
+	DefaultMailSender = "Marcus"
+)
+
+// Mail describes a new mail to be delivered to a profile.
+// A zero Expires duration creates mail that never expires.
+type Mail struct {
+	Sender  string        `json:"sender"`
+	Subject string        `json:"subject"`
+	Body    string        `json:"body"`
+	Expires time.Duration `json:"expires"`
+}
+
+// MailEntry is a decoded mail of a profile.
+// Expires is the zero time for mail that never expires, Attachment is nil for mail without gear.
+type MailEntry struct {
+	Guid       string     `json:"guid"`
+	Sender     string     `json:"sender"`
+	Subject    string     `json:"subject"`
+	Body       string     `json:"body"`
+	Sent       time.Time  `json:"sent"`
+	Expires    time.Time  `json:"expires"`
+	Read       bool       `json:"read"`
+	Attachment *item.Item `json:"attachment"`
+}
+
+/*
+GetMail decodes the mail of the profile, including the attached gear.
+Mail counts as read if it's marked read and not listed in UnreadMailGuids.
+Returns an error if an attachment can't be decoded, items of unknown categories are kept as they are.
+This requires a valid database to be set.
+*/
+func GetMail(p *pb.Profile) ([]MailEntry, error) {
+	entries := make([]MailEntry, len(p.NpcMailItems))
+	for index, m := range p.NpcMailItems {
+		e := MailEntry{
+			Guid:    m.MailGuid,
+			Sender:  m.SenderDisplayName,
+			Subject: m.Subject,
+			Body:    m.Body,
+			Sent:    time.Unix(m.DateSent, 0),
+			Read:    m.HasBeenRead && !containsGuid(p.UnreadMailGuids, m.MailGuid),
+		}
+		if m.ExpirationDate != 0 {
+			e.Expires = time.Unix(m.ExpirationDate, 0)
+		}
+		if m.GearSerialNumber != "" {
+			serial, err := DecodeMailSerial(m.GearSerialNumber)
+			if err != nil {
+				return nil, fmt.Errorf("mail %d: %v", index, err)
+			}
+			items, err := decodeSerials([][]byte{serial})
+			if err != nil {
+				return nil, fmt.Errorf("mail %d: %v", index, err)
+			}
+			e.Attachment = &items[0]
+		}
+		entries[index] = e
+	}
+	return entries, nil
+}
+
+/*
+GiftItem delivers an item serial as attachment of a new, unread mail with a fresh guid.
+*/
+func GiftItem(p *pb.Profile, serial []byte, m Mail) (*pb.OakMailItem, error) {
+	if len(serial) == 0 {
+		return nil, fmt.Errorf("item serial must not be empty")
+	}
+	guid, err := newMailGuid()
+	if err != nil {
+		return nil, err
+	}
+	if m.Sender == "" {
+		m.Sender = DefaultMailSender
+	}
+	now := time.Now()
+	mail := &pb.OakMailItem{
+		MailItemType:      MailTypeGear,
+		SenderDisplayName: m.Sender,
+		Subject:           m.Subject,
+		Body:              m.Body,
+		GearSerialNumber:  EncodeMailSerial(serial),
+		MailGuid:          guid,
+		DateSent:          now.Unix(),
+	}
+	if m.Expires > 0 {
+		mail.ExpirationDate = now.Add(m.Expires).Unix()
+	}
+	p.NpcMailItems = append(p.NpcMailItems, mail)
+	p.MailGuids = append(p.MailGuids, guid)
+	p.UnreadMailGuids = append(p.UnreadMailGuids, guid)
+	return mail, nil
+}
+
+/*
+MarkMailRead marks the mail with the given guid as read or unread.
+*/
+func MarkMailRead(p *pb.Profile, guid string, read bool) error {
+	var mail *pb.OakMailItem
+	for _, m := range p.NpcMailItems {
+		if strings.EqualFold(m.MailGuid, guid) {
+			mail = m
+			break
+		}
+	}
+	if mail == nil {
+		return fmt.Errorf("no mail with guid %s", guid)
+	}
+	mail.HasBeenRead = read
+	p.UnreadMailGuids = removeGuid(p.UnreadMailGuids, mail.MailGuid)
+	if !read {
+		p.UnreadMailGuids = append(p.UnreadMailGuids, mail.MailGuid)
+	}
+	return nil
+}
+
+/*
+PurgeExpiredMail removes all mail that expired before now and returns the number of removed mails.
+*/
+func PurgeExpiredMail(p *pb.Profile, now time.Time) int {
+	kept := make([]*pb.OakMailItem, 0, len(p.NpcMailItems))
+	for _, m := range p.NpcMailItems {
+		if m.ExpirationDate != 0 && m.ExpirationDate < now.Unix() {
+			p.MailGuids = removeGuid(p.MailGuids, m.MailGuid)
+			p.UnreadMailGuids = removeGuid(p.UnreadMailGuids, m.MailGuid)
+			continue
+		}
+		kept = append(kept, m)
+	}
+	removed := len(p.NpcMailItems) - len(kept)
+	p.NpcMailItems = kept
+	return removed
+}
+
+// EncodeMailSerial formats an item serial the way mail attachments store it, e.g. BL3(AwAAAA...).
+func EncodeMailSerial(serial []byte) string {
+	return "BL3(" + base64.StdEncoding.EncodeToString(serial) + ")"
+}
+
+// DecodeMailSerial parses a mail attachment serial. Both BL3(...) wrapped and bare base64 serials are accepted.
+func DecodeMailSerial(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(strings.ToUpper(s), "BL3(") && strings.HasSuffix(s, ")") {
+		s = s[4 : len(s)-1]
+	}
+	return base64.StdEncoding.DecodeString(s)
+}
+
+func newMailGuid() (string, error) {
+	bs := make([]byte, 16)
+	if _, err := rand.Read(bs); err != nil {
+		return "", err
+	}
+	return strings.ToUpper(hex.EncodeToString(bs)), nil
+}
+
+func containsGuid(guids []string, guid string) bool {
+	for _, g := range guids {
+		if strings.EqualFold(g, guid) {
+			return true
+		}
+	}
+	return false
+}
+
+func removeGuid(guids []string, guid string) []string {
+	result := guids[:0]
+	for _, g := range guids {
+		if !strings.EqualFold(g, guid) {
+			result = append(result, g)
+		}
+	}
+	return result
+}
@@ -0,0 +1,83 @@
+package profile
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/cfi2017/bl3-save-core/internal/testassets"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+func TestGiftItem(t *testing.T) {
+	p := &pb.Profile{}
+	serial := []byte{0x03, 0x00, 0x00, 0x00, 0x00, 0x01}
+	m, err := GiftItem(p, serial, Mail{Subject: "gift", Expires: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.MailGuid) != 32 || len(p.MailGuids) != 1 || len(p.UnreadMailGuids) != 1 {
+		t.Fatal("mail not registered")
+	}
+	decoded, err := DecodeMailSerial(m.GearSerialNumber)
+	if err != nil || !bytes.Equal(decoded, serial) {
+		t.Fatal("attachment serial mismatch")
+	}
+	if err := MarkMailRead(p, m.MailGuid, true); err != nil || len(p.UnreadMailGuids) != 0 || !m.HasBeenRead {
+		t.Fatal("mail not marked read")
+	}
+	if removed := PurgeExpiredMail(p, time.Now()); removed != 0 {
+		t.Fatal("purged unexpired mail")
+	}
+	if removed := PurgeExpiredMail(p, time.Now().Add(2*time.Hour)); removed != 1 || len(p.MailGuids) != 0 {
+		t.Fatal("expired mail not purged")
+	}
+}
+
+func TestGetMail(t *testing.T) {
+	defer testassets.Load()()
+	p := &pb.Profile{
+		NpcMailItems: []*pb.OakMailItem{{
+			SenderDisplayName: "Lilith",
+			Subject:           "note",
+			MailGuid:          "0123456789ABCDEF0123456789ABCDEF",
+			DateSent:          1600000000,
+			HasBeenRead:       true,
+		}},
+	}
+	gift, err := GiftItem(p, testassets.Serial(testassets.Item(50), 1), Mail{Subject: "gift", Expires: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mail, err := GetMail(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mail) != 2 {
+		t.Fatalf("expected 2 mails, got %d", len(mail))
+	}
+	note := mail[0]
+	if note.Sender != "Lilith" || !note.Read || note.Attachment != nil || !note.Expires.IsZero() || note.Sent.Unix() != 1600000000 {
+		t.Fatalf("unexpected mail %v", note)
+	}
+	if mail[1].Guid != gift.MailGuid || mail[1].Read || mail[1].Attachment == nil || mail[1].Attachment.Level != 50 ||
+		mail[1].Expires.Unix() != gift.ExpirationDate {
+		t.Fatalf("unexpected gift %v", mail[1])
+	}
+
+	if err := MarkMailRead(p, gift.MailGuid, true); err != nil {
+		t.Fatal(err)
+	}
+	if mail, err = GetMail(p); err != nil || !mail[1].Read {
+		t.Fatal("read state not reported")
+	}
+	p.UnreadMailGuids = append(p.UnreadMailGuids, gift.MailGuid)
+	if mail, err = GetMail(p); err != nil || mail[1].Read {
+		t.Fatal("mail listed as unread reported as read")
+	}
+
+	gift.GearSerialNumber = EncodeMailSerial([]byte{4, 0, 0, 0, 1, 2, 3})
+	if _, err := GetMail(p); err == nil {
+		t.Fatal("expected error for malformed attachment")
+	}
+}
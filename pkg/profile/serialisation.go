@@ -1,6 +1,7 @@
 package profile
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/cfi2017/bl3-save-core/pkg/pb"
@@ -57,11 +58,34 @@ func Deserialize(reader io.Reader, platform string) (shared2.SavFile, pb.Profile
 }
 
 func Serialize(writer io.Writer, s shared2.SavFile, p pb.Profile, platform string) {
-	bs, err := proto.Marshal(&p)
-	if err != nil {
+	if err := Encode(writer, s, &p, platform); err != nil {
 		panic(err)
 	}
+}
+
+/*
+Encode serializes a profile like Serialize, but returns errors instead of panicking.
+*/
+func Encode(writer io.Writer, s shared2.SavFile, p *pb.Profile, platform string) error {
+	if _, ok := platforms[platform]; !ok {
+		return fmt.Errorf("unknown platform %s", platform)
+	}
+	bs, err := proto.Marshal(p)
+	if err != nil {
+		return err
+	}
 	bs = shared2.Encrypt(bs, platforms[platform].Prefix, platforms[platform].Xor)
 	shared2.SerializeHeader(writer, s, bs)
+	return nil
+}
 
+/*
+Verify decodes a profile save and encodes it again without changes, reporting whether the result matches the original.
+*/
+func Verify(data []byte, platform string) (shared2.VerifyResult, error) {
+	magic, ok := platforms[platform]
+	if !ok {
+		return shared2.VerifyResult{}, fmt.Errorf("unknown platform %s", platform)
+	}
+	return shared2.Verify(data, magic, &pb.Profile{})
 }
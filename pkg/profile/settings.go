@@ -0,0 +1,148 @@
+package profile
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// SettingsVersion is the version of the settings document written by ExportSettings.
+const SettingsVersion = 1
+
+// SettingsDocument is a portable preset of a profile's options and input bindings.
+// Settings contains the exported profile fields in protobuf JSON form, keyed by their proto field name.
+type SettingsDocument struct {
+	Version  int             `json:"version"`
+	Settings json.RawMessage `json:"settings"`
+}
+
+var (
+	// nonSettingsFields are scalar profile fields that track state rather than player options.
+	nonSettingsFields = map[protoreflect.Name]bool{
+		"last_used_savegame_id":              true,
+		"last_status_menu_page":              true,
+		"max_cached_friend_events":           true,
+		"max_cached_friend_statuses":         true,
+		"last_whisper_fetch_events_time":     true,
+		"last_whisper_fetch_statuses_time":   true,
+		"max_friend_encounter_size":          true,
+		"has_seen_first_boot":                true,
+		"increased_chance_for_subscribers":   true,
+		"rare_chest_event_enabled":           true,
+		"badass_event_enabled":               true,
+		"pinata_event_enabled":               true,
+		"min_time_between_badass_events":     true,
+		"total_playtime_seconds":             true,
+		"moxxis_drink_event_enabled":         true,
+		"moxxis_drink_event_bits_product_id": true,
+		"default_dead_zone_inner_updated":    true,
+		"needs_shift_first_boot":             true,
+		"needs_shift_first_boot_primary":     true,
+		"player_selected_league":             true,
+	}
+	// nonSettingsPrefixes are prefixes of profile fields that track citizen science and streamer state.
+	nonSettingsPrefixes = []string{"CitizenScience", "Streamer", "bCitizenScience"}
+)
+
+/*
+ExportSettings exports the options and input bindings of a profile as a versioned JSON document.
+Bank, cosmetics, guardian rank, mail and other progression data is not exported.
+*/
+func ExportSettings(p *pb.Profile) ([]byte, error) {
+	src := p.ProtoReflect()
+	dst := (&pb.Profile{}).ProtoReflect()
+	for _, f := range settingsFields() {
+		copyField(dst, src, f)
+	}
+	settings, err := protojson.MarshalOptions{
+		UseProtoNames:   true,
+		EmitUnpopulated: true,
+	}.Marshal(dst.Interface())
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(SettingsDocument{
+		Version:  SettingsVersion,
+		Settings: settings,
+	}, "", "  ")
+}
+
+/*
+ImportSettings applies a settings document created by ExportSettings to a profile.
+Only settings present in the document are changed, everything else in the profile is left untouched.
+*/
+func ImportSettings(p *pb.Profile, data []byte) error {
+	var doc SettingsDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	if doc.Version < 1 || doc.Version > SettingsVersion {
+		return fmt.Errorf("unsupported settings version %d", doc.Version)
+	}
+	var present map[string]json.RawMessage
+	if err := json.Unmarshal(doc.Settings, &present); err != nil {
+		return err
+	}
+	settings := &pb.Profile{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(doc.Settings, settings); err != nil {
+		return err
+	}
+	src := settings.ProtoReflect()
+	dst := p.ProtoReflect()
+	for _, f := range settingsFields() {
+		if _, ok := present[string(f.Name())]; !ok {
+			if _, ok := present[f.JSONName()]; !ok {
+				continue
+			}
+		}
+		copyField(dst, src, f)
+	}
+	return nil
+}
+
+/*
+settingsFields returns the profile fields that are considered settings:
+all singular scalar fields that aren't state tracking fields, and the input bindings.
+*/
+func settingsFields() []protoreflect.FieldDescriptor {
+	fields := (&pb.Profile{}).ProtoReflect().Descriptor().Fields()
+	result := make([]protoreflect.FieldDescriptor, 0, fields.Len())
+	for i := 0; i < fields.Len(); i++ {
+		f := fields.Get(i)
+		if f.Name() == "player_input_bindings" {
+			result = append(result, f)
+			continue
+		}
+		if f.Cardinality() == protoreflect.Repeated || nonSettingsFields[f.Name()] || isNonSettingsPrefix(f.Name()) {
+			continue
+		}
+		switch f.Kind() {
+		case protoreflect.BoolKind, protoreflect.FloatKind, protoreflect.DoubleKind,
+			protoreflect.Int32Kind, protoreflect.Uint32Kind, protoreflect.StringKind:
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// copyField copies a field between messages, clearing it in dst if it's an unset message field in src.
+func copyField(dst, src protoreflect.Message, f protoreflect.FieldDescriptor) {
+	if f.Kind() == protoreflect.MessageKind && !src.Has(f) {
+		dst.Clear(f)
+		return
+	}
+	dst.Set(f, src.Get(f))
+}
+
+func isNonSettingsPrefix(name protoreflect.Name) bool {
+	for _, prefix := range nonSettingsPrefixes {
+		if strings.HasPrefix(string(name), prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package profile
+
+import (
+	"testing"
+
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+func TestExportImportSettings(t *testing.T) {
+	src := &pb.Profile{
+		GamepadInvertLook:         true,
+		GamepadLookDeadZoneInnerX: 0.25,
+		BaseFov:                   100,
+		MatchmakingRegion:         "eu",
+		PlayerInputBindings: &pb.PlayerInputBindings{Categories: []*pb.PlayerInputBinding_Category{
+			{CategoryDataPath: "/Game/Input/Category_Common"},
+		}},
+		BankInventoryList: [][]byte{{0x03}},
+		GuardianRank:      &pb.GuardianRankProfileData{GuardianRank: 100},
+	}
+	data, err := ExportSettings(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst := &pb.Profile{GuardianRank: &pb.GuardianRankProfileData{GuardianRank: 5}, EnableVibration: true}
+	if err := ImportSettings(dst, data); err != nil {
+		t.Fatal(err)
+	}
+	if !dst.GamepadInvertLook || dst.GamepadLookDeadZoneInnerX != 0.25 || dst.BaseFov != 100 || dst.MatchmakingRegion != "eu" {
+		t.Fatal("settings not imported")
+	}
+	if dst.EnableVibration {
+		t.Fatal("unset setting in export was not applied")
+	}
+	if len(dst.PlayerInputBindings.GetCategories()) != 1 {
+		t.Fatal("input bindings not imported")
+	}
+	if len(dst.BankInventoryList) != 0 || dst.GuardianRank.GuardianRank != 5 {
+		t.Fatal("non-settings data was imported")
+	}
+	if err := ImportSettings(dst, []byte(`{"version": 2, "settings": {}}`)); err == nil {
+		t.Fatal("expected error for unsupported version")
+	}
+	if err := ImportSettings(dst, []byte(`{"version": 1, "settings": {"base_fov": 90}}`)); err != nil || dst.BaseFov != 90 || !dst.GamepadInvertLook {
+		t.Fatal("partial settings import failed")
+	}
+}
+
+func TestExportSettingsWithoutBindings(t *testing.T) {
+	data, err := ExportSettings(&pb.Profile{BaseFov: 90})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst := &pb.Profile{PlayerInputBindings: &pb.PlayerInputBindings{}}
+	if err := ImportSettings(dst, data); err != nil {
+		t.Fatal(err)
+	}
+	if dst.PlayerInputBindings != nil || dst.BaseFov != 90 {
+		t.Fatal("settings not imported")
+	}
+}
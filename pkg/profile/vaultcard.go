@@ -0,0 +1,213 @@
+package profile
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/cfi2017/bl3-save-core/pkg/assets"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+// VaultCardReward is a position in a vault card's reward grid.
+type VaultCardReward struct {
+	Column int32 `json:"column"`
+	Row    int32 `json:"row"`
+}
+
+// VaultCardGear is a claimed gear reward of a vault card, by its index into the card's gear rewards.
+type VaultCardGear struct {
+	Index           int32  `json:"index"`
+	RepurchaseCount uint32 `json:"repurchaseCount"`
+}
+
+/*
+GetVaultCardLevel returns the level and experience of the given vault card.
+The level is derived from the experience and capped at the card's max level.
+Returns an error if the card's levels aren't known, they're read from the catalog.
+*/
+func GetVaultCardLevel(p *pb.Profile, id uint32) (int, int64, error) {
+	card, err := findVaultCardLevels(id)
+	if err != nil {
+		return 0, 0, err
+	}
+	var xp int64
+	if rewards := getVaultCardRewards(p, id, false); rewards != nil {
+		xp = rewards.VaultCardExperience
+	}
+	level := int(xp/card.ExperiencePerLevel) + 1
+	if level > card.MaxLevel {
+		level = card.MaxLevel
+	}
+	return level, xp, nil
+}
+
+/*
+SetVaultCardLevel sets the experience of the given vault card to the minimum required for the level.
+Returns an error if the card's levels aren't known, they're read from the catalog.
+*/
+func SetVaultCardLevel(p *pb.Profile, id uint32, level int) error {
+	card, err := findVaultCardLevels(id)
+	if err != nil {
+		return err
+	}
+	if level < 1 || level > card.MaxLevel {
+		return fmt.Errorf("invalid level %d for %s, must be between 1 and %d", level, card.Name, card.MaxLevel)
+	}
+	getVaultCardRewards(p, id, true).VaultCardExperience = int64(level-1) * card.ExperiencePerLevel
+	return nil
+}
+
+/*
+GetVaultCardRewards returns the unlocked and redeemed rewards of the given vault card.
+*/
+func GetVaultCardRewards(p *pb.Profile, id uint32) (unlocked []VaultCardReward, redeemed []VaultCardReward) {
+	unlocked, redeemed = make([]VaultCardReward, 0), make([]VaultCardReward, 0)
+	rewards := getVaultCardRewards(p, id, false)
+	if rewards == nil {
+		return
+	}
+	for _, r := range rewards.UnlockedRewardList {
+		unlocked = append(unlocked, VaultCardReward{Column: r.ColumnIndex, Row: r.RowIndex})
+	}
+	for _, r := range rewards.RedeemedRewardList {
+		redeemed = append(redeemed, VaultCardReward{Column: r.ColumnIndex, Row: r.RowIndex})
+	}
+	return
+}
+
+/*
+ClaimVaultCardReward unlocks and redeems a reward of the given vault card.
+The reward isn't checked against the card's reward grid, which isn't known.
+*/
+func ClaimVaultCardReward(p *pb.Profile, id uint32, reward VaultCardReward) error {
+	if reward.Column < 0 || reward.Row < 0 {
+		return fmt.Errorf("invalid reward %d/%d", reward.Column, reward.Row)
+	}
+	rewards := getVaultCardRewards(p, id, true)
+	if indexOfReward(rewards.UnlockedRewardList, reward) < 0 {
+		rewards.UnlockedRewardList = append(rewards.UnlockedRewardList, &pb.VaultCardReward{ColumnIndex: reward.Column, RowIndex: reward.Row})
+	}
+	if indexOfReward(rewards.RedeemedRewardList, reward) < 0 {
+		rewards.RedeemedRewardList = append(rewards.RedeemedRewardList, &pb.VaultCardReward{ColumnIndex: reward.Column, RowIndex: reward.Row})
+	}
+	return nil
+}
+
+/*
+UnclaimVaultCardReward marks a redeemed reward of the given vault card as not redeemed. The reward stays unlocked.
+*/
+func UnclaimVaultCardReward(p *pb.Profile, id uint32, reward VaultCardReward) error {
+	rewards := getVaultCardRewards(p, id, false)
+	if rewards == nil {
+		return fmt.Errorf("no rewards claimed on vault card %d", id)
+	}
+	i := indexOfReward(rewards.RedeemedRewardList, reward)
+	if i < 0 {
+		return fmt.Errorf("reward %d/%d of vault card %d is not claimed", reward.Column, reward.Row, id)
+	}
+	rewards.RedeemedRewardList = append(rewards.RedeemedRewardList[:i], rewards.RedeemedRewardList[i+1:]...)
+	return nil
+}
+
+/*
+GetVaultCardGear returns the claimed gear rewards of the given vault card.
+The rewards are only known by index, the items they stand for aren't known.
+*/
+func GetVaultCardGear(p *pb.Profile, id uint32) []VaultCardGear {
+	gear := make([]VaultCardGear, 0)
+	rewards := getVaultCardRewards(p, id, false)
+	if rewards == nil {
+		return gear
+	}
+	for _, g := range rewards.GearRewards {
+		gear = append(gear, VaultCardGear{Index: g.GearIndex, RepurchaseCount: g.RepurchaseCount})
+	}
+	return gear
+}
+
+/*
+ClaimVaultCardGear marks the gear reward with the given index of the given vault card as claimed.
+Gear rewards that are already claimed are left as is. The index isn't checked against the card's gear rewards,
+which aren't known.
+*/
+func ClaimVaultCardGear(p *pb.Profile, id uint32, index int32) error {
+	if index < 0 {
+		return fmt.Errorf("invalid gear reward %d", index)
+	}
+	rewards := getVaultCardRewards(p, id, true)
+	for _, g := range rewards.GearRewards {
+		if g.GearIndex == index {
+			return nil
+		}
+	}
+	rewards.GearRewards = append(rewards.GearRewards, &pb.VaultCardGearReward{GearIndex: index})
+	return nil
+}
+
+/*
+UnclaimVaultCardGear removes a claimed gear reward from the given vault card.
+*/
+func UnclaimVaultCardGear(p *pb.Profile, id uint32, index int32) error {
+	rewards := getVaultCardRewards(p, id, false)
+	if rewards != nil {
+		for i, g := range rewards.GearRewards {
+			if g.GearIndex == index {
+				rewards.GearRewards = append(rewards.GearRewards[:i], rewards.GearRewards[i+1:]...)
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("gear reward %d of vault card %d is not claimed", index, id)
+}
+
+/*
+ResetVaultCardChallenges forgets the previously rolled vault card challenges and rolls new weekly challenges.
+*/
+func ResetVaultCardChallenges(p *pb.Profile) {
+	if p.VaultCard == nil {
+		p.VaultCard = &pb.VaultCardSaveGameData{}
+	}
+	p.VaultCard.VaultCardPreviousChallenges = nil
+	p.VaultCard.CurrentWeekSeed = rand.Int31()
+}
+
+// findVaultCardLevels returns the registered vault card with the given id if its levels are known.
+func findVaultCardLevels(id uint32) (assets.VaultCard, error) {
+	card, ok := assets.FindVaultCard(id)
+	if !ok {
+		return card, fmt.Errorf("vault card %d not known, it's read from the catalog", id)
+	}
+	if card.MaxLevel <= 0 || card.ExperiencePerLevel <= 0 {
+		return card, fmt.Errorf("levels of %s not known, they're read from the catalog", card.Name)
+	}
+	return card, nil
+}
+
+func getVaultCardRewards(p *pb.Profile, id uint32, create bool) *pb.VaultCardRewardList {
+	if p.VaultCard == nil {
+		if !create {
+			return nil
+		}
+		p.VaultCard = &pb.VaultCardSaveGameData{}
+	}
+	for _, r := range p.VaultCard.VaultCardClaimedRewards {
+		if r.VaultCardId == id {
+			return r
+		}
+	}
+	if !create {
+		return nil
+	}
+	r := &pb.VaultCardRewardList{VaultCardId: id}
+	p.VaultCard.VaultCardClaimedRewards = append(p.VaultCard.VaultCardClaimedRewards, r)
+	return r
+}
+
+func indexOfReward(list []*pb.VaultCardReward, reward VaultCardReward) int {
+	for i, r := range list {
+		if r.ColumnIndex == reward.Column && r.RowIndex == reward.Row {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,72 @@
+package profile
+
+import (
+	"testing"
+
+	"github.com/cfi2017/bl3-save-core/internal/testassets"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+func TestVaultCard(t *testing.T) {
+	p := &pb.Profile{}
+	if err := SetVaultCardLevel(p, testassets.VaultCard, 10); err == nil {
+		t.Fatal("expected error for a vault card that isn't registered")
+	}
+	defer testassets.Catalog()()
+	if err := SetVaultCardLevel(p, testassets.VaultCard, 10); err != nil {
+		t.Fatal(err)
+	}
+	if level, _, err := GetVaultCardLevel(p, testassets.VaultCard); err != nil || level != 10 {
+		t.Fatalf("expected level 10, got %d (%v)", level, err)
+	}
+	if err := SetVaultCardLevel(p, testassets.VaultCard, 101); err == nil {
+		t.Fatal("expected error for level above max")
+	}
+	reward := VaultCardReward{Column: 2, Row: 1}
+	if err := ClaimVaultCardReward(p, testassets.VaultCard, reward); err != nil {
+		t.Fatal(err)
+	}
+	if unlocked, redeemed := GetVaultCardRewards(p, testassets.VaultCard); len(unlocked) != 1 || len(redeemed) != 1 {
+		t.Fatal("reward not claimed")
+	}
+	if err := UnclaimVaultCardReward(p, testassets.VaultCard, reward); err != nil {
+		t.Fatal(err)
+	}
+	if unlocked, redeemed := GetVaultCardRewards(p, testassets.VaultCard); len(unlocked) != 1 || len(redeemed) != 0 {
+		t.Fatal("reward not unclaimed")
+	}
+	p.VaultCard.VaultCardPreviousChallenges = []*pb.VaultCardPreviousChallenge{{PreviousChallengeId: 3}}
+	ResetVaultCardChallenges(p)
+	if len(p.VaultCard.VaultCardPreviousChallenges) != 0 {
+		t.Fatal("challenges not reset")
+	}
+}
+
+func TestVaultCardGear(t *testing.T) {
+	p := &pb.Profile{}
+	if gear := GetVaultCardGear(p, 2); len(gear) != 0 {
+		t.Fatalf("unexpected gear %v", gear)
+	}
+	for _, index := range []int32{3, 0, 3} {
+		if err := ClaimVaultCardGear(p, 2, index); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ClaimVaultCardGear(p, 2, -1); err == nil {
+		t.Fatal("expected error for a negative index")
+	}
+	p.VaultCard.VaultCardClaimedRewards[0].GearRewards[0].RepurchaseCount = 2
+	gear := GetVaultCardGear(p, 2)
+	if len(gear) != 2 || gear[0] != (VaultCardGear{Index: 3, RepurchaseCount: 2}) || gear[1] != (VaultCardGear{Index: 0}) {
+		t.Fatalf("unexpected gear %v", gear)
+	}
+	if err := UnclaimVaultCardGear(p, 2, 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := UnclaimVaultCardGear(p, 2, 3); err == nil {
+		t.Fatal("expected error for gear that isn't claimed")
+	}
+	if gear := GetVaultCardGear(p, 2); len(gear) != 1 || gear[0].Index != 0 {
+		t.Fatalf("unexpected gear %v", gear)
+	}
+}
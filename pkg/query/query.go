@@ -0,0 +1,263 @@
+/*
+Package query finds items in characters and profiles.
+
+A query is a list of terms separated by spaces, an item matches if it matches every term.
+Each term compares a field of the item with a value:
+
+	manufacturer=Vladof level>=65 category=AssaultRifles generics~Gunner location!=bank
+
+Fields are balance, manufacturer, inv_data, category, rarity, level, parts, generics, location and slot.
+Operators are = and != for equality, ~ and !~ for substrings and <, <=, >, >= for level and rarity.
+Strings are compared case-insensitively, = also matches the last element of a path, e.g. Vladof for
+/Game/Gear/Manufacturers/_Design/Vladof.Vladof. parts and generics match if any of their entries matches.
+Values can be quoted with double quotes and may list alternatives separated by commas, e.g. rarity=veryrare,legendary.
+*/
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cfi2017/bl3-save-core/pkg/item"
+)
+
+// Term is a single comparison of a query.
+type Term struct {
+	Field  string
+	Op     string
+	Values []string
+}
+
+// Query is a list of terms that must all match.
+type Query []Term
+
+// operators, longer operators first so <= isn't read as <
+var operators = []string{"!=", "!~", "<=", ">=", "=", "~", "<", ">"}
+
+// orderedFields are the fields supporting <, <=, > and >=.
+var orderedFields = map[string]bool{"level": true, "rarity": true}
+
+var fields = map[string]bool{
+	"balance": true, "manufacturer": true, "inv_data": true, "category": true, "rarity": true,
+	"level": true, "parts": true, "generics": true, "location": true, "slot": true,
+}
+
+/*
+Parse parses a query. An empty query matches every item.
+*/
+func Parse(s string) (Query, error) {
+	var q Query
+	for {
+		s = strings.TrimLeft(s, " \t\n")
+		if s == "" {
+			return q, nil
+		}
+		var t Term
+		var err error
+		if t, s, err = parseTerm(s); err != nil {
+			return nil, err
+		}
+		q = append(q, t)
+	}
+}
+
+// parseTerm parses the term at the start of s and returns the rest of s.
+func parseTerm(s string) (Term, string, error) {
+	end := strings.IndexAny(s, "!=~<> \t\n")
+	if end <= 0 {
+		return Term{}, "", fmt.Errorf("expected a field at %q", s)
+	}
+	t := Term{Field: strings.ToLower(s[:end])}
+	if !fields[t.Field] {
+		return Term{}, "", fmt.Errorf("unknown field %s", t.Field)
+	}
+	s = s[end:]
+	for _, op := range operators {
+		if strings.HasPrefix(s, op) {
+			t.Op = op
+			break
+		}
+	}
+	if t.Op == "" {
+		return Term{}, "", fmt.Errorf("expected an operator after %s", t.Field)
+	}
+	if strings.ContainsAny(t.Op, "<>") && !orderedFields[t.Field] {
+		return Term{}, "", fmt.Errorf("%s can't be compared with %s", t.Field, t.Op)
+	}
+	s = s[len(t.Op):]
+	var value string
+	if strings.HasPrefix(s, `"`) {
+		end := strings.Index(s[1:], `"`)
+		if end < 0 {
+			return Term{}, "", fmt.Errorf("unterminated quote in %s", t.Field)
+		}
+		value, s = s[1:end+1], s[end+2:]
+	} else {
+		end := strings.IndexAny(s, " \t\n")
+		if end < 0 {
+			end = len(s)
+		}
+		value, s = s[:end], s[end:]
+	}
+	if value == "" {
+		return Term{}, "", fmt.Errorf("expected a value for %s", t.Field)
+	}
+	t.Values = strings.Split(value, ",")
+	for _, v := range t.Values {
+		if err := t.check(v); err != nil {
+			return Term{}, "", err
+		}
+	}
+	return t, s, nil
+}
+
+func (t Term) check(value string) error {
+	switch t.Field {
+	case "level":
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("invalid level %q", value)
+		}
+	case "rarity":
+		if item.ParseRarity(value) == item.RarityUnknown && !strings.EqualFold(value, item.RarityUnknown.String()) {
+			return fmt.Errorf("unknown rarity %q", value)
+		}
+	case "location":
+		switch Location(strings.ToLower(value)) {
+		case Backpack, Equipped, Bank, LostLoot:
+		default:
+			if !strings.HasSuffix(t.Op, "~") {
+				return fmt.Errorf("unknown location %q", value)
+			}
+		}
+	}
+	return nil
+}
+
+func (t Term) String() string {
+	return t.Field + t.Op + strings.Join(t.Values, ",")
+}
+
+func (q Query) String() string {
+	terms := make([]string, len(q))
+	for i, t := range q {
+		terms[i] = t.String()
+	}
+	return strings.Join(terms, " ")
+}
+
+// Candidate is an item together with where it is stored, as seen by a query.
+type Candidate struct {
+	Item     item.Item
+	Location Location
+	// Slot is the name of the equipment slot of equipped items, e.g. weapon1.
+	Slot string
+}
+
+/*
+Match reports whether an item matches every term of the query.
+*/
+func (q Query) Match(c Candidate) bool {
+	for _, t := range q {
+		if !t.match(c) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t Term) match(c Candidate) bool {
+	switch t.Field {
+	case "level":
+		return t.compare(c.Item.Level, func(v string) int {
+			n, _ := strconv.Atoi(v)
+			return n
+		})
+	case "rarity":
+		return t.compare(int(item.GetRarity(c.Item)), func(v string) int {
+			return int(item.ParseRarity(v))
+		})
+	case "parts", "generics":
+		values := c.Item.Parts
+		if t.Field == "generics" {
+			values = c.Item.Generics
+		}
+		found := false
+		for _, v := range values {
+			if t.matchString(v) {
+				found = true
+				break
+			}
+		}
+		return found != negated(t.Op)
+	}
+	var value string
+	switch t.Field {
+	case "balance":
+		value = c.Item.Balance
+	case "manufacturer":
+		value = c.Item.Manufacturer
+	case "inv_data":
+		value = c.Item.InvData
+	case "category":
+		value = item.GetCategory(c.Item)
+	case "location":
+		value = string(c.Location)
+	case "slot":
+		value = c.Slot
+	}
+	return t.matchString(value) != negated(t.Op)
+}
+
+// compare compares a number with the values of an ordered field, = and != compare with any of the values.
+func (t Term) compare(n int, parse func(string) int) bool {
+	found := false
+	for _, v := range t.Values {
+		m := parse(v)
+		var ok bool
+		switch t.Op {
+		case "=", "!=", "~", "!~":
+			ok = n == m
+		case "<":
+			ok = n < m
+		case "<=":
+			ok = n <= m
+		case ">":
+			ok = n > m
+		case ">=":
+			ok = n >= m
+		}
+		if ok {
+			found = true
+			break
+		}
+	}
+	return found != negated(t.Op)
+}
+
+// matchString reports whether a string matches any of the values, ignoring negation.
+func (t Term) matchString(s string) bool {
+	s = strings.ToLower(s)
+	for _, v := range t.Values {
+		v = strings.ToLower(v)
+		if strings.HasSuffix(t.Op, "~") {
+			if strings.Contains(s, v) {
+				return true
+			}
+			continue
+		}
+		if s == v || shortName(s) == v || s[strings.LastIndex(s, "/")+1:] == v {
+			return true
+		}
+	}
+	return false
+}
+
+func negated(op string) bool {
+	return strings.HasPrefix(op, "!")
+}
+
+// shortName returns the object name of an asset path, e.g. Vladof for /Game/Gear/Manufacturers/_Design/Vladof.Vladof.
+func shortName(path string) string {
+	return path[strings.LastIndexAny(path, "/.")+1:]
+}
@@ -0,0 +1,53 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/cfi2017/bl3-save-core/pkg/item"
+)
+
+func TestMatch(t *testing.T) {
+	ar := Candidate{
+		Item: item.Item{
+			Level:        65,
+			Balance:      "/Game/Gear/Weapons/AssaultRifles/Vladof/_Shared/_Design/_Manufacturer/Balance/Balance_AR_VLA_04_VeryRare.Balance_AR_VLA_04_VeryRare",
+			Manufacturer: "/Game/Gear/Manufacturers/_Design/Vladof.Vladof",
+			InvData:      "/Game/Gear/Weapons/AssaultRifles/Vladof/_Shared/_Design/_Manufacturer/AR_VLA.AR_VLA",
+			Parts:        []string{"/Game/Gear/Weapons/AssaultRifles/Vladof/_Shared/_Design/Parts/Barrel/Part_AR_VLA_Barrel_01.Part_AR_VLA_Barrel_01"},
+			Generics:     []string{"/Game/Gear/Weapons/_Shared/_Design/EndGameParts/Character/Gunner/GPart_Gunner_WeaponDamageAndMagAfterAction.GPart_Gunner_WeaponDamageAndMagAfterAction"},
+		},
+		Location: Equipped,
+		Slot:     "weapon1",
+	}
+	tests := []struct {
+		query string
+		match bool
+	}{
+		{"", true},
+		{"manufacturer=Vladof level=65 category=AssaultRifles generics~gunner", true},
+		{"manufacturer=Jakobs", false},
+		{"manufacturer!=Jakobs,Hyperion", true},
+		{"level>=60 level<65", false},
+		{"rarity>=veryrare", true},
+		{"rarity=legendary", false},
+		{"location=backpack,equipped slot=weapon1", true},
+		{"location!=equipped", false},
+		{`balance="Balance_AR_VLA_04_VeryRare"`, true},
+		{"parts~barrel_01 generics!~Siren", true},
+	}
+	for _, test := range tests {
+		q, err := Parse(test.query)
+		if err != nil {
+			t.Fatalf("%q: %v", test.query, err)
+		}
+		if q.Match(ar) != test.match {
+			t.Errorf("%q: expected match to be %v", test.query, test.match)
+		}
+	}
+
+	for _, invalid := range []string{"colour=red", "level", "level=high", "balance>x", "rarity=shiny", "location=vault", `balance="x`} {
+		if _, err := Parse(invalid); err == nil {
+			t.Errorf("%q: expected an error", invalid)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package query
+
+import (
+	"errors"
+
+	"github.com/cfi2017/bl3-save-core/pkg/character"
+	"github.com/cfi2017/bl3-save-core/pkg/item"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+// Location is where an item is stored.
+type Location string
+
+const (
+	Backpack Location = "backpack"
+	Equipped Location = "equipped"
+	Bank     Location = "bank"
+	LostLoot Location = "lost-loot"
+)
+
+// Result is an item matching a query.
+type Result struct {
+	// Source names the save the item was found in, e.g. its file name.
+	Source string
+	Candidate
+	// Index of the item in its list, InventoryItems for backpack and equipped items,
+	// BankInventoryList or LostLootInventoryList for profiles.
+	Index  int
+	Serial []byte
+}
+
+/*
+Character returns the backpack and equipped items of a character that match the query.
+Items that can't be decoded are skipped. This requires a valid database to be set.
+*/
+func Character(q Query, source string, c *pb.Character) ([]Result, error) {
+	slots := map[int]string{}
+	for slot, index := range character.GetEquipped(c) {
+		slots[index] = slot
+	}
+	var results []Result
+	for i, data := range c.InventoryItems {
+		location := Backpack
+		slot, equipped := slots[i]
+		if equipped {
+			location = Equipped
+		}
+		r, err := search(q, source, location, slot, i, data.ItemSerialNumber)
+		if err != nil {
+			return nil, err
+		}
+		if r != nil {
+			results = append(results, *r)
+		}
+	}
+	return results, nil
+}
+
+/*
+Profile returns the bank and lost loot items of a profile that match the query.
+Items that can't be decoded are skipped. This requires a valid database to be set.
+*/
+func Profile(q Query, source string, p *pb.Profile) ([]Result, error) {
+	var results []Result
+	lists := []struct {
+		location Location
+		serials  [][]byte
+	}{{Bank, p.BankInventoryList}, {LostLoot, p.LostLootInventoryList}}
+	for _, l := range lists {
+		for i, serial := range l.serials {
+			r, err := search(q, source, l.location, "", i, serial)
+			if err != nil {
+				return nil, err
+			}
+			if r != nil {
+				results = append(results, *r)
+			}
+		}
+	}
+	return results, nil
+}
+
+/*
+search decodes a single item and returns it if it matches. A missing database is returned as an error,
+malformed items are skipped.
+*/
+func search(q Query, source string, location Location, slot string, index int, serial []byte) (*Result, error) {
+	i, err := item.Decode(serial)
+	var dbErr *item.DatabaseError
+	if errors.As(err, &dbErr) {
+		return nil, err
+	}
+	// items of unknown categories are decoded without parts, they can still match on their balance
+	if err != nil && !i.SkipIntrospection || i.Balance == "" {
+		return nil, nil
+	}
+	c := Candidate{Item: i, Location: location, Slot: slot}
+	if !q.Match(c) {
+		return nil, nil
+	}
+	return &Result{Source: source, Candidate: c, Index: index, Serial: serial}, nil
+}
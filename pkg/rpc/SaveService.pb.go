@@ -0,0 +1,1523 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.20.0-devel
+// 	protoc        (unknown)
+// source: SaveService.proto
+
+package rpc
+
+import (
+	context "context"
+	pb "github.com/cfi2017/bl3-save-core/pkg/pb"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+// SaveHeader is the GVAS header of a save file.
+type SaveHeader struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SgVersion          int32               `protobuf:"varint,1,opt,name=sg_version,json=sgVersion,proto3" json:"sg_version,omitempty"`
+	PkgVersion         int32               `protobuf:"varint,2,opt,name=pkg_version,json=pkgVersion,proto3" json:"pkg_version,omitempty"`
+	EngineMajorVersion int32               `protobuf:"varint,3,opt,name=engine_major_version,json=engineMajorVersion,proto3" json:"engine_major_version,omitempty"`
+	EngineMinorVersion int32               `protobuf:"varint,4,opt,name=engine_minor_version,json=engineMinorVersion,proto3" json:"engine_minor_version,omitempty"`
+	EnginePatchVersion int32               `protobuf:"varint,5,opt,name=engine_patch_version,json=enginePatchVersion,proto3" json:"engine_patch_version,omitempty"`
+	EngineBuildVersion int32               `protobuf:"varint,6,opt,name=engine_build_version,json=engineBuildVersion,proto3" json:"engine_build_version,omitempty"`
+	BuildId            string              `protobuf:"bytes,7,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	FmtVersion         int32               `protobuf:"varint,8,opt,name=fmt_version,json=fmtVersion,proto3" json:"fmt_version,omitempty"`
+	CustomFormatData   []*CustomFormatData `protobuf:"bytes,9,rep,name=custom_format_data,json=customFormatData,proto3" json:"custom_format_data,omitempty"`
+	SgType             string              `protobuf:"bytes,10,opt,name=sg_type,json=sgType,proto3" json:"sg_type,omitempty"`
+}
+
+func (x *SaveHeader) Reset() {
+	*x = SaveHeader{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_SaveService_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SaveHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveHeader) ProtoMessage() {}
+
+func (x *SaveHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_SaveService_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveHeader.ProtoReflect.Descriptor instead.
+func (*SaveHeader) Descriptor() ([]byte, []int) {
+	return file_SaveService_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SaveHeader) GetSgVersion() int32 {
+	if x != nil {
+		return x.SgVersion
+	}
+	return 0
+}
+
+func (x *SaveHeader) GetPkgVersion() int32 {
+	if x != nil {
+		return x.PkgVersion
+	}
+	return 0
+}
+
+func (x *SaveHeader) GetEngineMajorVersion() int32 {
+	if x != nil {
+		return x.EngineMajorVersion
+	}
+	return 0
+}
+
+func (x *SaveHeader) GetEngineMinorVersion() int32 {
+	if x != nil {
+		return x.EngineMinorVersion
+	}
+	return 0
+}
+
+func (x *SaveHeader) GetEnginePatchVersion() int32 {
+	if x != nil {
+		return x.EnginePatchVersion
+	}
+	return 0
+}
+
+func (x *SaveHeader) GetEngineBuildVersion() int32 {
+	if x != nil {
+		return x.EngineBuildVersion
+	}
+	return 0
+}
+
+func (x *SaveHeader) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+func (x *SaveHeader) GetFmtVersion() int32 {
+	if x != nil {
+		return x.FmtVersion
+	}
+	return 0
+}
+
+func (x *SaveHeader) GetCustomFormatData() []*CustomFormatData {
+	if x != nil {
+		return x.CustomFormatData
+	}
+	return nil
+}
+
+func (x *SaveHeader) GetSgType() string {
+	if x != nil {
+		return x.SgType
+	}
+	return ""
+}
+
+type CustomFormatData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Guid  string `protobuf:"bytes,1,opt,name=guid,proto3" json:"guid,omitempty"`
+	Entry int32  `protobuf:"varint,2,opt,name=entry,proto3" json:"entry,omitempty"`
+}
+
+func (x *CustomFormatData) Reset() {
+	*x = CustomFormatData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_SaveService_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CustomFormatData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomFormatData) ProtoMessage() {}
+
+func (x *CustomFormatData) ProtoReflect() protoreflect.Message {
+	mi := &file_SaveService_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomFormatData.ProtoReflect.Descriptor instead.
+func (*CustomFormatData) Descriptor() ([]byte, []int) {
+	return file_SaveService_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CustomFormatData) GetGuid() string {
+	if x != nil {
+		return x.Guid
+	}
+	return ""
+}
+
+func (x *CustomFormatData) GetEntry() int32 {
+	if x != nil {
+		return x.Entry
+	}
+	return 0
+}
+
+type DecodeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data     []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Platform string `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"`
+}
+
+func (x *DecodeRequest) Reset() {
+	*x = DecodeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_SaveService_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DecodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecodeRequest) ProtoMessage() {}
+
+func (x *DecodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_SaveService_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecodeRequest.ProtoReflect.Descriptor instead.
+func (*DecodeRequest) Descriptor() ([]byte, []int) {
+	return file_SaveService_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DecodeRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *DecodeRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+type EncodeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *EncodeResponse) Reset() {
+	*x = EncodeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_SaveService_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EncodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncodeResponse) ProtoMessage() {}
+
+func (x *EncodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_SaveService_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EncodeResponse.ProtoReflect.Descriptor instead.
+func (*EncodeResponse) Descriptor() ([]byte, []int) {
+	return file_SaveService_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *EncodeResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type CharacterSave struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Header    *SaveHeader   `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Character *pb.Character `protobuf:"bytes,2,opt,name=character,proto3" json:"character,omitempty"`
+}
+
+func (x *CharacterSave) Reset() {
+	*x = CharacterSave{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_SaveService_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CharacterSave) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CharacterSave) ProtoMessage() {}
+
+func (x *CharacterSave) ProtoReflect() protoreflect.Message {
+	mi := &file_SaveService_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CharacterSave.ProtoReflect.Descriptor instead.
+func (*CharacterSave) Descriptor() ([]byte, []int) {
+	return file_SaveService_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CharacterSave) GetHeader() *SaveHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *CharacterSave) GetCharacter() *pb.Character {
+	if x != nil {
+		return x.Character
+	}
+	return nil
+}
+
+type EncodeCharacterRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Save     *CharacterSave `protobuf:"bytes,1,opt,name=save,proto3" json:"save,omitempty"`
+	Platform string         `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"`
+}
+
+func (x *EncodeCharacterRequest) Reset() {
+	*x = EncodeCharacterRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_SaveService_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EncodeCharacterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncodeCharacterRequest) ProtoMessage() {}
+
+func (x *EncodeCharacterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_SaveService_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EncodeCharacterRequest.ProtoReflect.Descriptor instead.
+func (*EncodeCharacterRequest) Descriptor() ([]byte, []int) {
+	return file_SaveService_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *EncodeCharacterRequest) GetSave() *CharacterSave {
+	if x != nil {
+		return x.Save
+	}
+	return nil
+}
+
+func (x *EncodeCharacterRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+type ProfileSave struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Header  *SaveHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Profile *pb.Profile `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
+}
+
+func (x *ProfileSave) Reset() {
+	*x = ProfileSave{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_SaveService_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProfileSave) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileSave) ProtoMessage() {}
+
+func (x *ProfileSave) ProtoReflect() protoreflect.Message {
+	mi := &file_SaveService_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileSave.ProtoReflect.Descriptor instead.
+func (*ProfileSave) Descriptor() ([]byte, []int) {
+	return file_SaveService_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ProfileSave) GetHeader() *SaveHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *ProfileSave) GetProfile() *pb.Profile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+type EncodeProfileRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Save     *ProfileSave `protobuf:"bytes,1,opt,name=save,proto3" json:"save,omitempty"`
+	Platform string       `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"`
+}
+
+func (x *EncodeProfileRequest) Reset() {
+	*x = EncodeProfileRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_SaveService_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EncodeProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncodeProfileRequest) ProtoMessage() {}
+
+func (x *EncodeProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_SaveService_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EncodeProfileRequest.ProtoReflect.Descriptor instead.
+func (*EncodeProfileRequest) Descriptor() ([]byte, []int) {
+	return file_SaveService_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *EncodeProfileRequest) GetSave() *ProfileSave {
+	if x != nil {
+		return x.Save
+	}
+	return nil
+}
+
+func (x *EncodeProfileRequest) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+// Item is a decoded item serial. Serial is the original serial, its seed is reused when encoding.
+type Item struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Level         int32    `protobuf:"varint,1,opt,name=level,proto3" json:"level,omitempty"`
+	Balance       string   `protobuf:"bytes,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	Manufacturer  string   `protobuf:"bytes,3,opt,name=manufacturer,proto3" json:"manufacturer,omitempty"`
+	InvData       string   `protobuf:"bytes,4,opt,name=inv_data,json=invData,proto3" json:"inv_data,omitempty"`
+	Parts         []string `protobuf:"bytes,5,rep,name=parts,proto3" json:"parts,omitempty"`
+	Generics      []string `protobuf:"bytes,6,rep,name=generics,proto3" json:"generics,omitempty"`
+	Overflow      string   `protobuf:"bytes,7,opt,name=overflow,proto3" json:"overflow,omitempty"`
+	Version       uint64   `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`
+	SerialVersion uint32   `protobuf:"varint,9,opt,name=serial_version,json=serialVersion,proto3" json:"serial_version,omitempty"`
+	Serial        []byte   `protobuf:"bytes,10,opt,name=serial,proto3" json:"serial,omitempty"`
+}
+
+func (x *Item) Reset() {
+	*x = Item{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_SaveService_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Item) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Item) ProtoMessage() {}
+
+func (x *Item) ProtoReflect() protoreflect.Message {
+	mi := &file_SaveService_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Item.ProtoReflect.Descriptor instead.
+func (*Item) Descriptor() ([]byte, []int) {
+	return file_SaveService_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Item) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Item) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+func (x *Item) GetManufacturer() string {
+	if x != nil {
+		return x.Manufacturer
+	}
+	return ""
+}
+
+func (x *Item) GetInvData() string {
+	if x != nil {
+		return x.InvData
+	}
+	return ""
+}
+
+func (x *Item) GetParts() []string {
+	if x != nil {
+		return x.Parts
+	}
+	return nil
+}
+
+func (x *Item) GetGenerics() []string {
+	if x != nil {
+		return x.Generics
+	}
+	return nil
+}
+
+func (x *Item) GetOverflow() string {
+	if x != nil {
+		return x.Overflow
+	}
+	return ""
+}
+
+func (x *Item) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Item) GetSerialVersion() uint32 {
+	if x != nil {
+		return x.SerialVersion
+	}
+	return 0
+}
+
+func (x *Item) GetSerial() []byte {
+	if x != nil {
+		return x.Serial
+	}
+	return nil
+}
+
+type DecodeItemRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Serial []byte `protobuf:"bytes,1,opt,name=serial,proto3" json:"serial,omitempty"`
+}
+
+func (x *DecodeItemRequest) Reset() {
+	*x = DecodeItemRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_SaveService_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DecodeItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecodeItemRequest) ProtoMessage() {}
+
+func (x *DecodeItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_SaveService_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecodeItemRequest.ProtoReflect.Descriptor instead.
+func (*DecodeItemRequest) Descriptor() ([]byte, []int) {
+	return file_SaveService_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DecodeItemRequest) GetSerial() []byte {
+	if x != nil {
+		return x.Serial
+	}
+	return nil
+}
+
+// DecodeItemResponse holds either the decoded item or the reason it couldn't be decoded.
+type DecodeItemResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Item  *Item  `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *DecodeItemResponse) Reset() {
+	*x = DecodeItemResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_SaveService_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DecodeItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecodeItemResponse) ProtoMessage() {}
+
+func (x *DecodeItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_SaveService_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecodeItemResponse.ProtoReflect.Descriptor instead.
+func (*DecodeItemResponse) Descriptor() ([]byte, []int) {
+	return file_SaveService_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DecodeItemResponse) GetItem() *Item {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *DecodeItemResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type EncodeItemsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items []*Item `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *EncodeItemsRequest) Reset() {
+	*x = EncodeItemsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_SaveService_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EncodeItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncodeItemsRequest) ProtoMessage() {}
+
+func (x *EncodeItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_SaveService_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EncodeItemsRequest.ProtoReflect.Descriptor instead.
+func (*EncodeItemsRequest) Descriptor() ([]byte, []int) {
+	return file_SaveService_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *EncodeItemsRequest) GetItems() []*Item {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type EncodeItemsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Serials [][]byte `protobuf:"bytes,1,rep,name=serials,proto3" json:"serials,omitempty"`
+}
+
+func (x *EncodeItemsResponse) Reset() {
+	*x = EncodeItemsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_SaveService_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EncodeItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncodeItemsResponse) ProtoMessage() {}
+
+func (x *EncodeItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_SaveService_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EncodeItemsResponse.ProtoReflect.Descriptor instead.
+func (*EncodeItemsResponse) Descriptor() ([]byte, []int) {
+	return file_SaveService_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *EncodeItemsResponse) GetSerials() [][]byte {
+	if x != nil {
+		return x.Serials
+	}
+	return nil
+}
+
+var File_SaveService_proto protoreflect.FileDescriptor
+
+var file_SaveService_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x53, 0x61, 0x76, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x53, 0x61, 0x76, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x1a, 0x0d, 0x4f, 0x61, 0x6b, 0x53, 0x61, 0x76, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x10, 0x4f, 0x61, 0x6b, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0xb6, 0x03, 0x0a, 0x0a, 0x53, 0x61, 0x76, 0x65, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x67, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x73, 0x67, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x1f, 0x0a, 0x0b, 0x70, 0x6b, 0x67, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x70, 0x6b, 0x67, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x30, 0x0a, 0x14, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x5f, 0x6d, 0x61, 0x6a, 0x6f, 0x72,
+	0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12,
+	0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x4d, 0x61, 0x6a, 0x6f, 0x72, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x30, 0x0a, 0x14, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x5f, 0x6d, 0x69, 0x6e,
+	0x6f, 0x72, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x12, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x4d, 0x69, 0x6e, 0x6f, 0x72, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x30, 0x0a, 0x14, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x5f, 0x70,
+	0x61, 0x74, 0x63, 0x68, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x12, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x50, 0x61, 0x74, 0x63, 0x68, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x30, 0x0a, 0x14, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65,
+	0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x12, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x42, 0x75, 0x69, 0x6c,
+	0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x6d, 0x74, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x66, 0x6d, 0x74, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x4b, 0x0a, 0x12, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x5f, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43,
+	0x75, 0x73, 0x74, 0x6f, 0x6d, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x44, 0x61, 0x74, 0x61, 0x52,
+	0x10, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x44, 0x61, 0x74,
+	0x61, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x67, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x22, 0x3c, 0x0a, 0x10, 0x43, 0x75,
+	0x73, 0x74, 0x6f, 0x6d, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x44, 0x61, 0x74, 0x61, 0x12, 0x12,
+	0x0a, 0x04, 0x67, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x67, 0x75,
+	0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x22, 0x3f, 0x0a, 0x0d, 0x44, 0x65, 0x63, 0x6f,
+	0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a,
+	0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x22, 0x24, 0x0a, 0x0e, 0x45, 0x6e, 0x63,
+	0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22,
+	0x72, 0x0a, 0x0d, 0x43, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x53, 0x61, 0x76, 0x65,
+	0x12, 0x2f, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x53,
+	0x61, 0x76, 0x65, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x12, 0x30, 0x0a, 0x09, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x4f, 0x61, 0x6b, 0x53, 0x61, 0x76, 0x65, 0x2e, 0x43,
+	0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x52, 0x09, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63,
+	0x74, 0x65, 0x72, 0x22, 0x64, 0x0a, 0x16, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x43, 0x68, 0x61,
+	0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2e, 0x0a,
+	0x04, 0x73, 0x61, 0x76, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x53, 0x61,
+	0x76, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x68, 0x61, 0x72, 0x61, 0x63,
+	0x74, 0x65, 0x72, 0x53, 0x61, 0x76, 0x65, 0x52, 0x04, 0x73, 0x61, 0x76, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x22, 0x6a, 0x0a, 0x0b, 0x50, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x53, 0x61, 0x76, 0x65, 0x12, 0x2f, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x48, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x07, 0x70, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x4f, 0x61, 0x6b,
+	0x53, 0x61, 0x76, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x07, 0x70, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x22, 0x60, 0x0a, 0x14, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x50,
+	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a,
+	0x04, 0x73, 0x61, 0x76, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x53, 0x61,
+	0x76, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x53, 0x61, 0x76, 0x65, 0x52, 0x04, 0x73, 0x61, 0x76, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70,
+	0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70,
+	0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x22, 0x9c, 0x02, 0x0a, 0x04, 0x49, 0x74, 0x65, 0x6d,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65,
+	0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74,
+	0x75, 0x72, 0x65, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x6e, 0x76, 0x5f, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x6e, 0x76, 0x44, 0x61, 0x74, 0x61, 0x12,
+	0x14, 0x0a, 0x05, 0x70, 0x61, 0x72, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05,
+	0x70, 0x61, 0x72, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x69, 0x63,
+	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x69, 0x63,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x76, 0x65, 0x72, 0x66, 0x6c, 0x6f, 0x77, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x76, 0x65, 0x72, 0x66, 0x6c, 0x6f, 0x77, 0x12, 0x18, 0x0a,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x72, 0x69, 0x61,
+	0x6c, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
+	0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x22, 0x2b, 0x0a, 0x11, 0x44, 0x65, 0x63, 0x6f, 0x64, 0x65,
+	0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x65, 0x72, 0x69, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x65, 0x72,
+	0x69, 0x61, 0x6c, 0x22, 0x51, 0x0a, 0x12, 0x44, 0x65, 0x63, 0x6f, 0x64, 0x65, 0x49, 0x74, 0x65,
+	0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x04, 0x69, 0x74, 0x65,
+	0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x04, 0x69, 0x74, 0x65, 0x6d,
+	0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x3d, 0x0a, 0x12, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65,
+	0x49, 0x74, 0x65, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x05,
+	0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x53, 0x61,
+	0x76, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x05,
+	0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0x2f, 0x0a, 0x13, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x49,
+	0x74, 0x65, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x73,
+	0x65, 0x72, 0x69, 0x61, 0x6c, 0x73, 0x32, 0xeb, 0x03, 0x0a, 0x0b, 0x53, 0x61, 0x76, 0x65, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x49, 0x0a, 0x0f, 0x44, 0x65, 0x63, 0x6f, 0x64, 0x65,
+	0x43, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x12, 0x1a, 0x2e, 0x53, 0x61, 0x76, 0x65,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x44, 0x65, 0x63, 0x6f, 0x64, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x43, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x53, 0x61, 0x76,
+	0x65, 0x12, 0x53, 0x0a, 0x0f, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x43, 0x68, 0x61, 0x72, 0x61,
+	0x63, 0x74, 0x65, 0x72, 0x12, 0x23, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x43, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74,
+	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x53, 0x61, 0x76, 0x65,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x45, 0x0a, 0x0d, 0x44, 0x65, 0x63, 0x6f, 0x64, 0x65,
+	0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x1a, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x44, 0x65, 0x63, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x61, 0x76, 0x65, 0x12, 0x4f, 0x0a,
+	0x0d, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x21,
+	0x2e, 0x53, 0x61, 0x76, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x45, 0x6e, 0x63,
+	0x6f, 0x64, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1b, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52,
+	0x0a, 0x0b, 0x44, 0x65, 0x63, 0x6f, 0x64, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x1e, 0x2e,
+	0x53, 0x61, 0x76, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x44, 0x65, 0x63, 0x6f,
+	0x64, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e,
+	0x53, 0x61, 0x76, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x44, 0x65, 0x63, 0x6f,
+	0x64, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01,
+	0x30, 0x01, 0x12, 0x50, 0x0a, 0x0b, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x49, 0x74, 0x65, 0x6d,
+	0x73, 0x12, 0x1f, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x20, 0x2e, 0x53, 0x61, 0x76, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x42, 0x2a, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x63, 0x66, 0x69, 0x32, 0x30, 0x31, 0x37, 0x2f, 0x62, 0x6c, 0x33, 0x2d, 0x73,
+	0x61, 0x76, 0x65, 0x2d, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x72, 0x70, 0x63,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_SaveService_proto_rawDescOnce sync.Once
+	file_SaveService_proto_rawDescData = file_SaveService_proto_rawDesc
+)
+
+func file_SaveService_proto_rawDescGZIP() []byte {
+	file_SaveService_proto_rawDescOnce.Do(func() {
+		file_SaveService_proto_rawDescData = protoimpl.X.CompressGZIP(file_SaveService_proto_rawDescData)
+	})
+	return file_SaveService_proto_rawDescData
+}
+
+var file_SaveService_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_SaveService_proto_goTypes = []interface{}{
+	(*SaveHeader)(nil),             // 0: SaveService.SaveHeader
+	(*CustomFormatData)(nil),       // 1: SaveService.CustomFormatData
+	(*DecodeRequest)(nil),          // 2: SaveService.DecodeRequest
+	(*EncodeResponse)(nil),         // 3: SaveService.EncodeResponse
+	(*CharacterSave)(nil),          // 4: SaveService.CharacterSave
+	(*EncodeCharacterRequest)(nil), // 5: SaveService.EncodeCharacterRequest
+	(*ProfileSave)(nil),            // 6: SaveService.ProfileSave
+	(*EncodeProfileRequest)(nil),   // 7: SaveService.EncodeProfileRequest
+	(*Item)(nil),                   // 8: SaveService.Item
+	(*DecodeItemRequest)(nil),      // 9: SaveService.DecodeItemRequest
+	(*DecodeItemResponse)(nil),     // 10: SaveService.DecodeItemResponse
+	(*EncodeItemsRequest)(nil),     // 11: SaveService.EncodeItemsRequest
+	(*EncodeItemsResponse)(nil),    // 12: SaveService.EncodeItemsResponse
+	(*pb.Character)(nil),           // 13: OakSave.Character
+	(*pb.Profile)(nil),             // 14: OakSave.Profile
+}
+var file_SaveService_proto_depIdxs = []int32{
+	1,  // 0: SaveService.SaveHeader.custom_format_data:type_name -> SaveService.CustomFormatData
+	0,  // 1: SaveService.CharacterSave.header:type_name -> SaveService.SaveHeader
+	13, // 2: SaveService.CharacterSave.character:type_name -> OakSave.Character
+	4,  // 3: SaveService.EncodeCharacterRequest.save:type_name -> SaveService.CharacterSave
+	0,  // 4: SaveService.ProfileSave.header:type_name -> SaveService.SaveHeader
+	14, // 5: SaveService.ProfileSave.profile:type_name -> OakSave.Profile
+	6,  // 6: SaveService.EncodeProfileRequest.save:type_name -> SaveService.ProfileSave
+	8,  // 7: SaveService.DecodeItemResponse.item:type_name -> SaveService.Item
+	8,  // 8: SaveService.EncodeItemsRequest.items:type_name -> SaveService.Item
+	2,  // 9: SaveService.SaveService.DecodeCharacter:input_type -> SaveService.DecodeRequest
+	5,  // 10: SaveService.SaveService.EncodeCharacter:input_type -> SaveService.EncodeCharacterRequest
+	2,  // 11: SaveService.SaveService.DecodeProfile:input_type -> SaveService.DecodeRequest
+	7,  // 12: SaveService.SaveService.EncodeProfile:input_type -> SaveService.EncodeProfileRequest
+	9,  // 13: SaveService.SaveService.DecodeItems:input_type -> SaveService.DecodeItemRequest
+	11, // 14: SaveService.SaveService.EncodeItems:input_type -> SaveService.EncodeItemsRequest
+	4,  // 15: SaveService.SaveService.DecodeCharacter:output_type -> SaveService.CharacterSave
+	3,  // 16: SaveService.SaveService.EncodeCharacter:output_type -> SaveService.EncodeResponse
+	6,  // 17: SaveService.SaveService.DecodeProfile:output_type -> SaveService.ProfileSave
+	3,  // 18: SaveService.SaveService.EncodeProfile:output_type -> SaveService.EncodeResponse
+	10, // 19: SaveService.SaveService.DecodeItems:output_type -> SaveService.DecodeItemResponse
+	12, // 20: SaveService.SaveService.EncodeItems:output_type -> SaveService.EncodeItemsResponse
+	15, // [15:21] is the sub-list for method output_type
+	9,  // [9:15] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_SaveService_proto_init() }
+func file_SaveService_proto_init() {
+	if File_SaveService_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_SaveService_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SaveHeader); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_SaveService_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CustomFormatData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_SaveService_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DecodeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_SaveService_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EncodeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_SaveService_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CharacterSave); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_SaveService_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EncodeCharacterRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_SaveService_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProfileSave); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_SaveService_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EncodeProfileRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_SaveService_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Item); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_SaveService_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DecodeItemRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_SaveService_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DecodeItemResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_SaveService_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EncodeItemsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_SaveService_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EncodeItemsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_SaveService_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_SaveService_proto_goTypes,
+		DependencyIndexes: file_SaveService_proto_depIdxs,
+		MessageInfos:      file_SaveService_proto_msgTypes,
+	}.Build()
+	File_SaveService_proto = out.File
+	file_SaveService_proto_rawDesc = nil
+	file_SaveService_proto_goTypes = nil
+	file_SaveService_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// SaveServiceClient is the client API for SaveService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SaveServiceClient interface {
+	DecodeCharacter(ctx context.Context, in *DecodeRequest, opts ...grpc.CallOption) (*CharacterSave, error)
+	EncodeCharacter(ctx context.Context, in *EncodeCharacterRequest, opts ...grpc.CallOption) (*EncodeResponse, error)
+	DecodeProfile(ctx context.Context, in *DecodeRequest, opts ...grpc.CallOption) (*ProfileSave, error)
+	EncodeProfile(ctx context.Context, in *EncodeProfileRequest, opts ...grpc.CallOption) (*EncodeResponse, error)
+	// DecodeItems decodes a stream of serials, answering each request in order.
+	DecodeItems(ctx context.Context, opts ...grpc.CallOption) (SaveService_DecodeItemsClient, error)
+	EncodeItems(ctx context.Context, in *EncodeItemsRequest, opts ...grpc.CallOption) (*EncodeItemsResponse, error)
+}
+
+type saveServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSaveServiceClient(cc grpc.ClientConnInterface) SaveServiceClient {
+	return &saveServiceClient{cc}
+}
+
+func (c *saveServiceClient) DecodeCharacter(ctx context.Context, in *DecodeRequest, opts ...grpc.CallOption) (*CharacterSave, error) {
+	out := new(CharacterSave)
+	err := c.cc.Invoke(ctx, "/SaveService.SaveService/DecodeCharacter", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *saveServiceClient) EncodeCharacter(ctx context.Context, in *EncodeCharacterRequest, opts ...grpc.CallOption) (*EncodeResponse, error) {
+	out := new(EncodeResponse)
+	err := c.cc.Invoke(ctx, "/SaveService.SaveService/EncodeCharacter", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *saveServiceClient) DecodeProfile(ctx context.Context, in *DecodeRequest, opts ...grpc.CallOption) (*ProfileSave, error) {
+	out := new(ProfileSave)
+	err := c.cc.Invoke(ctx, "/SaveService.SaveService/DecodeProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *saveServiceClient) EncodeProfile(ctx context.Context, in *EncodeProfileRequest, opts ...grpc.CallOption) (*EncodeResponse, error) {
+	out := new(EncodeResponse)
+	err := c.cc.Invoke(ctx, "/SaveService.SaveService/EncodeProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *saveServiceClient) DecodeItems(ctx context.Context, opts ...grpc.CallOption) (SaveService_DecodeItemsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SaveService_serviceDesc.Streams[0], "/SaveService.SaveService/DecodeItems", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &saveServiceDecodeItemsClient{stream}
+	return x, nil
+}
+
+type SaveService_DecodeItemsClient interface {
+	Send(*DecodeItemRequest) error
+	Recv() (*DecodeItemResponse, error)
+	grpc.ClientStream
+}
+
+type saveServiceDecodeItemsClient struct {
+	grpc.ClientStream
+}
+
+func (x *saveServiceDecodeItemsClient) Send(m *DecodeItemRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *saveServiceDecodeItemsClient) Recv() (*DecodeItemResponse, error) {
+	m := new(DecodeItemResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *saveServiceClient) EncodeItems(ctx context.Context, in *EncodeItemsRequest, opts ...grpc.CallOption) (*EncodeItemsResponse, error) {
+	out := new(EncodeItemsResponse)
+	err := c.cc.Invoke(ctx, "/SaveService.SaveService/EncodeItems", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SaveServiceServer is the server API for SaveService service.
+type SaveServiceServer interface {
+	DecodeCharacter(context.Context, *DecodeRequest) (*CharacterSave, error)
+	EncodeCharacter(context.Context, *EncodeCharacterRequest) (*EncodeResponse, error)
+	DecodeProfile(context.Context, *DecodeRequest) (*ProfileSave, error)
+	EncodeProfile(context.Context, *EncodeProfileRequest) (*EncodeResponse, error)
+	// DecodeItems decodes a stream of serials, answering each request in order.
+	DecodeItems(SaveService_DecodeItemsServer) error
+	EncodeItems(context.Context, *EncodeItemsRequest) (*EncodeItemsResponse, error)
+}
+
+// UnimplementedSaveServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedSaveServiceServer struct {
+}
+
+func (*UnimplementedSaveServiceServer) DecodeCharacter(context.Context, *DecodeRequest) (*CharacterSave, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DecodeCharacter not implemented")
+}
+func (*UnimplementedSaveServiceServer) EncodeCharacter(context.Context, *EncodeCharacterRequest) (*EncodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EncodeCharacter not implemented")
+}
+func (*UnimplementedSaveServiceServer) DecodeProfile(context.Context, *DecodeRequest) (*ProfileSave, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DecodeProfile not implemented")
+}
+func (*UnimplementedSaveServiceServer) EncodeProfile(context.Context, *EncodeProfileRequest) (*EncodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EncodeProfile not implemented")
+}
+func (*UnimplementedSaveServiceServer) DecodeItems(SaveService_DecodeItemsServer) error {
+	return status.Errorf(codes.Unimplemented, "method DecodeItems not implemented")
+}
+func (*UnimplementedSaveServiceServer) EncodeItems(context.Context, *EncodeItemsRequest) (*EncodeItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EncodeItems not implemented")
+}
+
+func RegisterSaveServiceServer(s *grpc.Server, srv SaveServiceServer) {
+	s.RegisterService(&_SaveService_serviceDesc, srv)
+}
+
+func _SaveService_DecodeCharacter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SaveServiceServer).DecodeCharacter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SaveService.SaveService/DecodeCharacter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SaveServiceServer).DecodeCharacter(ctx, req.(*DecodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SaveService_EncodeCharacter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EncodeCharacterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SaveServiceServer).EncodeCharacter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SaveService.SaveService/EncodeCharacter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SaveServiceServer).EncodeCharacter(ctx, req.(*EncodeCharacterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SaveService_DecodeProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SaveServiceServer).DecodeProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SaveService.SaveService/DecodeProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SaveServiceServer).DecodeProfile(ctx, req.(*DecodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SaveService_EncodeProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EncodeProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SaveServiceServer).EncodeProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SaveService.SaveService/EncodeProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SaveServiceServer).EncodeProfile(ctx, req.(*EncodeProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SaveService_DecodeItems_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SaveServiceServer).DecodeItems(&saveServiceDecodeItemsServer{stream})
+}
+
+type SaveService_DecodeItemsServer interface {
+	Send(*DecodeItemResponse) error
+	Recv() (*DecodeItemRequest, error)
+	grpc.ServerStream
+}
+
+type saveServiceDecodeItemsServer struct {
+	grpc.ServerStream
+}
+
+func (x *saveServiceDecodeItemsServer) Send(m *DecodeItemResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *saveServiceDecodeItemsServer) Recv() (*DecodeItemRequest, error) {
+	m := new(DecodeItemRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _SaveService_EncodeItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EncodeItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SaveServiceServer).EncodeItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SaveService.SaveService/EncodeItems",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SaveServiceServer).EncodeItems(ctx, req.(*EncodeItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _SaveService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "SaveService.SaveService",
+	HandlerType: (*SaveServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "DecodeCharacter",
+			Handler:    _SaveService_DecodeCharacter_Handler,
+		},
+		{
+			MethodName: "EncodeCharacter",
+			Handler:    _SaveService_EncodeCharacter_Handler,
+		},
+		{
+			MethodName: "DecodeProfile",
+			Handler:    _SaveService_DecodeProfile_Handler,
+		},
+		{
+			MethodName: "EncodeProfile",
+			Handler:    _SaveService_EncodeProfile_Handler,
+		},
+		{
+			MethodName: "EncodeItems",
+			Handler:    _SaveService_EncodeItems_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DecodeItems",
+			Handler:       _SaveService_DecodeItems_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "SaveService.proto",
+}
@@ -0,0 +1,224 @@
+package rpc
+
+//go:generate protoc -I . -I ../pb --go_out=plugins=grpc,paths=source_relative:. SaveService.proto
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/cfi2017/bl3-save-core/pkg/character"
+	"github.com/cfi2017/bl3-save-core/pkg/item"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+	"github.com/cfi2017/bl3-save-core/pkg/profile"
+	"github.com/cfi2017/bl3-save-core/pkg/shared"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultPlatform = "pc"
+
+var platforms = []string{"pc", "ps4"}
+
+// Server implements SaveServiceServer with the character, profile and item packages.
+// Item operations require a valid database to be set.
+type Server struct {
+	UnimplementedSaveServiceServer
+}
+
+/*
+NewServer creates a save service. Register it with RegisterSaveServiceServer.
+*/
+func NewServer() *Server {
+	return &Server{}
+}
+
+func (s *Server) DecodeCharacter(_ context.Context, req *DecodeRequest) (*CharacterSave, error) {
+	platform, err := getPlatform(req.Platform)
+	if err != nil {
+		return nil, err
+	}
+	res := &CharacterSave{}
+	err = shared.Catch(func() error {
+		h, c, err := character.Deserialize(bytes.NewReader(req.Data), platform)
+		res.Header, res.Character = headerToPB(h), &c
+		return err
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid character save: %v", err)
+	}
+	return res, nil
+}
+
+func (s *Server) EncodeCharacter(_ context.Context, req *EncodeCharacterRequest) (*EncodeResponse, error) {
+	platform, err := getPlatform(req.Platform)
+	if err != nil {
+		return nil, err
+	}
+	if req.Save.GetCharacter() == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing character")
+	}
+	buf := new(bytes.Buffer)
+	err = shared.Catch(func() error {
+		return character.Encode(buf, headerFromPB(req.Save.Header), req.Save.Character, platform)
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "couldn't encode character: %v", err)
+	}
+	return &EncodeResponse{Data: buf.Bytes()}, nil
+}
+
+func (s *Server) DecodeProfile(_ context.Context, req *DecodeRequest) (*ProfileSave, error) {
+	platform, err := getPlatform(req.Platform)
+	if err != nil {
+		return nil, err
+	}
+	res := &ProfileSave{}
+	err = shared.Catch(func() error {
+		h, p, err := profile.Deserialize(bytes.NewReader(req.Data), platform)
+		res.Header, res.Profile = headerToPB(h), &p
+		return err
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid profile save: %v", err)
+	}
+	return res, nil
+}
+
+func (s *Server) EncodeProfile(_ context.Context, req *EncodeProfileRequest) (*EncodeResponse, error) {
+	platform, err := getPlatform(req.Platform)
+	if err != nil {
+		return nil, err
+	}
+	if req.Save.GetProfile() == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing profile")
+	}
+	buf := new(bytes.Buffer)
+	err = shared.Catch(func() error {
+		return profile.Encode(buf, headerFromPB(req.Save.Header), req.Save.Profile, platform)
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "couldn't encode profile: %v", err)
+	}
+	return &EncodeResponse{Data: buf.Bytes()}, nil
+}
+
+/*
+DecodeItems answers every serial received on the stream with the decoded item, or the reason it couldn't be decoded.
+*/
+func (s *Server) DecodeItems(stream SaveService_DecodeItemsServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		res := &DecodeItemResponse{}
+		if i, err := item.Decode(req.Serial); err != nil {
+			res.Error = err.Error()
+		} else {
+			res.Item = itemToPB(i, req.Serial)
+		}
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) EncodeItems(_ context.Context, req *EncodeItemsRequest) (*EncodeItemsResponse, error) {
+	res := &EncodeItemsResponse{Serials: make([][]byte, len(req.Items))}
+	for index, i := range req.Items {
+		err := shared.Catch(func() (err error) {
+			res.Serials[index], err = item.Reserialize(itemFromPB(i))
+			return
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "item %d: %v", index, err)
+		}
+	}
+	return res, nil
+}
+
+func getPlatform(platform string) (string, error) {
+	if platform == "" {
+		return defaultPlatform, nil
+	}
+	for _, p := range platforms {
+		if p == platform {
+			return platform, nil
+		}
+	}
+	return "", status.Errorf(codes.InvalidArgument, "unknown platform %s", platform)
+}
+
+func headerToPB(s shared.SavFile) *SaveHeader {
+	h := &SaveHeader{
+		SgVersion:          int32(s.SgVersion),
+		PkgVersion:         int32(s.PkgVersion),
+		EngineMajorVersion: int32(s.EngineMajorVersion),
+		EngineMinorVersion: int32(s.EngineMinorVersion),
+		EnginePatchVersion: int32(s.EnginePatchVersion),
+		EngineBuildVersion: int32(s.EngineBuildVersion),
+		BuildId:            s.BuildId,
+		FmtVersion:         int32(s.FmtVersion),
+		SgType:             s.SgType,
+	}
+	for _, d := range s.CustomFmtData {
+		h.CustomFormatData = append(h.CustomFormatData, &CustomFormatData{Guid: d.Guid, Entry: int32(d.Entry)})
+	}
+	return h
+}
+
+func headerFromPB(h *SaveHeader) shared.SavFile {
+	s := shared.SavFile{
+		SgVersion:          int(h.GetSgVersion()),
+		PkgVersion:         int(h.GetPkgVersion()),
+		EngineMajorVersion: int(h.GetEngineMajorVersion()),
+		EngineMinorVersion: int(h.GetEngineMinorVersion()),
+		EnginePatchVersion: int(h.GetEnginePatchVersion()),
+		EngineBuildVersion: int(h.GetEngineBuildVersion()),
+		BuildId:            h.GetBuildId(),
+		FmtVersion:         int(h.GetFmtVersion()),
+		SgType:             h.GetSgType(),
+	}
+	for _, d := range h.GetCustomFormatData() {
+		s.CustomFmtData = append(s.CustomFmtData, shared.CustomFormatData{Guid: d.Guid, Entry: int(d.Entry)})
+	}
+	s.FmtCount = len(s.CustomFmtData)
+	return s
+}
+
+func itemToPB(i item.Item, serial []byte) *Item {
+	return &Item{
+		Level:         int32(i.Level),
+		Balance:       i.Balance,
+		Manufacturer:  i.Manufacturer,
+		InvData:       i.InvData,
+		Parts:         i.Parts,
+		Generics:      i.Generics,
+		Overflow:      i.Overflow,
+		Version:       i.Version,
+		SerialVersion: uint32(i.SerialVersion),
+		Serial:        serial,
+	}
+}
+
+func itemFromPB(i *Item) item.Item {
+	result := item.Item{
+		Level:         int(i.Level),
+		Balance:       i.Balance,
+		Manufacturer:  i.Manufacturer,
+		InvData:       i.InvData,
+		Parts:         i.Parts,
+		Generics:      i.Generics,
+		Overflow:      i.Overflow,
+		Version:       i.Version,
+		SerialVersion: uint8(i.SerialVersion),
+	}
+	if i.Serial != nil {
+		result.Wrapper = &pb.OakInventoryItemSaveGameData{ItemSerialNumber: i.Serial}
+	}
+	return result
+}
@@ -0,0 +1,113 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/cfi2017/bl3-save-core/internal/testassets"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestClient(t *testing.T) (SaveServiceClient, func()) {
+	l := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	RegisterSaveServiceServer(s, NewServer())
+	go func() { _ = s.Serve(l) }()
+	conn, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return l.Dial()
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewSaveServiceClient(conn), func() {
+		conn.Close()
+		s.Stop()
+	}
+}
+
+func TestCharacterRoundTrip(t *testing.T) {
+	client, stop := newTestClient(t)
+	defer stop()
+	ctx := context.Background()
+	save := &CharacterSave{
+		Header: &SaveHeader{
+			SgVersion:        2,
+			BuildId:          "OAK-PATCHDIESEL1-280",
+			CustomFormatData: []*CustomFormatData{{Guid: "0123456789abcdef0123456789abcdef", Entry: 1}},
+			SgType:           "OakSaveGame",
+		},
+		Character: &pb.Character{SaveGameId: 2, PreferredCharacterName: "Test"},
+	}
+	encoded, err := client.EncodeCharacter(ctx, &EncodeCharacterRequest{Save: save, Platform: "ps4"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := client.DecodeCharacter(ctx, &DecodeRequest{Data: encoded.Data, Platform: "ps4"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Character.PreferredCharacterName != "Test" || decoded.Header.BuildId != save.Header.BuildId {
+		t.Fatal("character changed in round trip")
+	}
+	again, err := client.EncodeCharacter(ctx, &EncodeCharacterRequest{Save: decoded, Platform: "ps4"})
+	if err != nil || !bytes.Equal(again.Data, encoded.Data) {
+		t.Fatal("save changed in round trip")
+	}
+	if _, err := client.DecodeCharacter(ctx, &DecodeRequest{Data: []byte("garbage")}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+	if _, err := client.DecodeProfile(ctx, &DecodeRequest{Platform: "xbox"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+}
+
+func TestDecodeItems(t *testing.T) {
+	defer testassets.Load()()
+	client, stop := newTestClient(t)
+	defer stop()
+	serials := [][]byte{
+		testassets.Serial(testassets.Item(50), 1),
+		[]byte("garbage"),
+		testassets.Serial(testassets.Item(30), 2),
+	}
+	stream, err := client.DecodeItems(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// every request is answered before the next one is sent
+	res := make([]*DecodeItemResponse, 0, len(serials))
+	for _, serial := range serials {
+		if err := stream.Send(&DecodeItemRequest{Serial: serial}); err != nil {
+			t.Fatal(err)
+		}
+		r, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		res = append(res, r)
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Fatalf("expected end of stream, got %v", err)
+	}
+
+	if res[0].Error != "" || res[0].Item.GetLevel() != 50 || !bytes.Equal(res[0].Item.GetSerial(), serials[0]) {
+		t.Fatalf("invalid first item: %v", res[0])
+	}
+	if res[1].Error == "" || res[1].Item != nil {
+		t.Fatalf("expected error for invalid serial, got %v", res[1])
+	}
+	if res[2].Error != "" || res[2].Item.GetLevel() != 30 {
+		t.Fatalf("stream didn't recover after an invalid serial: %v", res[2])
+	}
+}
@@ -0,0 +1,194 @@
+package script
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/cfi2017/bl3-save-core/pkg/item"
+	"go.starlark.net/starlark"
+)
+
+// itemValue is an item as seen by scripts.
+type itemValue struct {
+	item     item.Item
+	original []byte
+	// index in the list the item was loaded from, -1 for new items
+	index    int
+	flags    int32
+	parts    *starlark.List
+	generics *starlark.List
+	changed  bool
+}
+
+var itemAttrs = []string{"balance", "category", "flags", "generics", "inv_data", "level", "manufacturer", "parts", "rarity", "serial"}
+
+/*
+newItem decodes a serial into an item value. Items that can't be decoded, e.g. because their category is unknown,
+are kept as they are and can't be changed. A missing database is reported as an error.
+*/
+func newItem(serial []byte, index int) (*itemValue, error) {
+	i, err := item.Decode(serial)
+	var dbErr *item.DatabaseError
+	if errors.As(err, &dbErr) {
+		return nil, err
+	}
+	if err != nil {
+		i = item.Item{}
+	}
+	return &itemValue{
+		item:     i,
+		original: serial,
+		index:    index,
+		parts:    stringList(i.Parts),
+		generics: stringList(i.Generics),
+	}, nil
+}
+
+func (v *itemValue) String() string {
+	if v.item.Balance == "" {
+		return "<item " + base64.StdEncoding.EncodeToString(v.original) + ">"
+	}
+	return fmt.Sprintf("<item %s level %d>", v.item.Balance, v.item.Level)
+}
+func (v *itemValue) Type() string          { return "item" }
+func (v *itemValue) Freeze()               {}
+func (v *itemValue) Truth() starlark.Bool  { return true }
+func (v *itemValue) Hash() (uint32, error) { return 0, fmt.Errorf("unhashable type: item") }
+func (v *itemValue) AttrNames() []string   { return itemAttrs }
+
+func (v *itemValue) Attr(name string) (starlark.Value, error) {
+	switch name {
+	case "level":
+		return starlark.MakeInt(v.item.Level), nil
+	case "balance":
+		return starlark.String(v.item.Balance), nil
+	case "manufacturer":
+		return starlark.String(v.item.Manufacturer), nil
+	case "inv_data":
+		return starlark.String(v.item.InvData), nil
+	case "parts":
+		return v.parts, nil
+	case "generics":
+		return v.generics, nil
+	case "flags":
+		return starlark.MakeInt(int(v.flags)), nil
+	case "rarity":
+		return starlark.String(item.GetRarity(v.item).String()), nil
+	case "category":
+		return starlark.String(item.GetCategory(v.item)), nil
+	case "serial":
+		serial, err := v.serial()
+		if err != nil {
+			return nil, err
+		}
+		return starlark.String("BL3(" + base64.StdEncoding.EncodeToString(serial) + ")"), nil
+	}
+	return nil, nil
+}
+
+func (v *itemValue) SetField(name string, value starlark.Value) error {
+	if name == "flags" {
+		flags, err := starlark.AsInt32(value)
+		if err != nil {
+			return err
+		}
+		v.flags = int32(flags)
+		return nil
+	}
+	if v.item.Balance == "" {
+		return fmt.Errorf("item couldn't be decoded and can't be changed")
+	}
+	switch name {
+	case "level":
+		level, err := starlark.AsInt32(value)
+		if err != nil {
+			return err
+		}
+		v.item.Level = level
+	case "balance", "manufacturer", "inv_data":
+		s, ok := starlark.AsString(value)
+		if !ok {
+			return fmt.Errorf("%s must be a string, got %s", name, value.Type())
+		}
+		switch name {
+		case "balance":
+			v.item.Balance = s
+		case "manufacturer":
+			v.item.Manufacturer = s
+		default:
+			v.item.InvData = s
+		}
+	case "parts", "generics":
+		list, ok := value.(*starlark.List)
+		if !ok {
+			return fmt.Errorf("%s must be a list, got %s", name, value.Type())
+		}
+		if name == "parts" {
+			v.parts = list
+		} else {
+			v.generics = list
+		}
+	default:
+		return starlark.NoSuchAttrError(fmt.Sprintf("can't set item attribute %s", name))
+	}
+	v.changed = true
+	return nil
+}
+
+// serial returns the serial of the item, which is only serialized again if the item was changed.
+func (v *itemValue) serial() ([]byte, error) {
+	if v.item.Balance == "" {
+		return v.original, nil
+	}
+	parts, err := listStrings(v.parts)
+	if err != nil {
+		return nil, fmt.Errorf("parts: %v", err)
+	}
+	generics, err := listStrings(v.generics)
+	if err != nil {
+		return nil, fmt.Errorf("generics: %v", err)
+	}
+	if !v.changed && equalStrings(parts, v.item.Parts) && equalStrings(generics, v.item.Generics) {
+		return v.original, nil
+	}
+	i := v.item
+	i.Parts, i.Generics = parts, generics
+	seed, err := item.GetSeedFromSerial(v.original)
+	if err != nil {
+		return nil, err
+	}
+	return item.Serialize(i, seed)
+}
+
+func stringList(values []string) *starlark.List {
+	list := make([]starlark.Value, len(values))
+	for i, s := range values {
+		list[i] = starlark.String(s)
+	}
+	return starlark.NewList(list)
+}
+
+func listStrings(list *starlark.List) ([]string, error) {
+	values := make([]string, list.Len())
+	for i := range values {
+		s, ok := starlark.AsString(list.Index(i))
+		if !ok {
+			return nil, fmt.Errorf("expected string, got %s", list.Index(i).Type())
+		}
+		values[i] = s
+	}
+	return values, nil
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
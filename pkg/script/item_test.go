@@ -0,0 +1,91 @@
+package script
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cfi2017/bl3-save-core/internal/testassets"
+	"github.com/cfi2017/bl3-save-core/pkg/item"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+)
+
+func TestItems(t *testing.T) {
+	defer testassets.Load()()
+	serials := [][]byte{
+		testassets.Serial(testassets.Item(10), 1),
+		testassets.Serial(testassets.Item(20), 2),
+		testassets.Serial(testassets.Item(30), 3),
+	}
+	c := &pb.Character{
+		InventoryItems: []*pb.OakInventoryItemSaveGameData{
+			{ItemSerialNumber: serials[0], PickupOrderIndex: 5},
+			{ItemSerialNumber: serials[1], PickupOrderIndex: 6, Flags: 2},
+			{ItemSerialNumber: serials[2], PickupOrderIndex: 7},
+		},
+		EquippedInventoryList: []*pb.EquippedInventorySaveGameData{
+			{SlotDataPath: "weapon1", InventoryListIndex: 2},
+			{SlotDataPath: "weapon2", InventoryListIndex: 0},
+		},
+	}
+	src := `
+items = save.items
+items.pop(0)
+items[0].level = 50
+items.append(bl3.decode_item(items[1].serial))
+print(items[1].serial, items[0].flags)
+`
+	out := new(bytes.Buffer)
+	if err := RunCharacter("items.star", []byte(src), c, Options{Out: out}); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.InventoryItems) != 3 {
+		t.Fatalf("expected 3 items, got %d", len(c.InventoryItems))
+	}
+	changed, unchanged, added := c.InventoryItems[0], c.InventoryItems[1], c.InventoryItems[2]
+	if changed.PickupOrderIndex != 6 || changed.Flags != 2 || unchanged.PickupOrderIndex != 7 || added.PickupOrderIndex != 8 {
+		t.Fatalf("unexpected pickup order or flags: %v", c.InventoryItems)
+	}
+	i, err := item.Decode(changed.ItemSerialNumber)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seed, _ := item.GetSeedFromSerial(changed.ItemSerialNumber); i.Level != 50 || seed != 2 {
+		t.Fatalf("changed item not reserialized: level %d, seed %d", i.Level, seed)
+	}
+	if !bytes.Equal(unchanged.ItemSerialNumber, serials[2]) || !bytes.Equal(added.ItemSerialNumber, serials[2]) {
+		t.Fatal("unchanged items must keep their serial")
+	}
+	if c.EquippedInventoryList[0].InventoryListIndex != 1 || c.EquippedInventoryList[1].InventoryListIndex != -1 {
+		t.Fatalf("equipped items not remapped: %v", c.EquippedInventoryList)
+	}
+	if !strings.HasPrefix(out.String(), "BL3(") || !strings.HasSuffix(out.String(), " 2\n") {
+		t.Errorf("unexpected output %q", out.String())
+	}
+}
+
+func TestItemsActiveWeapons(t *testing.T) {
+	defer testassets.Load()()
+	c := &pb.Character{
+		InventoryItems: []*pb.OakInventoryItemSaveGameData{
+			{ItemSerialNumber: testassets.Serial(testassets.Item(10), 1)},
+			{ItemSerialNumber: testassets.Serial(testassets.Item(20), 2)},
+			{ItemSerialNumber: testassets.Serial(testassets.Item(30), 3)},
+		},
+		EquippedInventoryList: []*pb.EquippedInventorySaveGameData{
+			{SlotDataPath: "/Test/BPInvSlot_Weapon1.BPInvSlot_Weapon1", InventoryListIndex: 0},
+			{SlotDataPath: "/Test/BPInvSlot_Weapon2.BPInvSlot_Weapon2", InventoryListIndex: 1},
+			{SlotDataPath: "/Test/BPInvSlot_Weapon3.BPInvSlot_Weapon3", InventoryListIndex: 2},
+		},
+		ActiveWeaponList: []int32{0, 1},
+	}
+	if err := RunCharacter("items.star", []byte("save.items.pop(0)"), c, Options{}); err != nil {
+		t.Fatal(err)
+	}
+	if c.EquippedInventoryList[0].InventoryListIndex != -1 {
+		t.Fatalf("removed item is still equipped: %v", c.EquippedInventoryList)
+	}
+	if c.ActiveWeaponList[0] != 2 || c.ActiveWeaponList[1] != 1 {
+		t.Fatalf("held weapon not switched to an equipped slot: %v", c.ActiveWeaponList)
+	}
+}
@@ -0,0 +1,209 @@
+package script
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cfi2017/bl3-save-core/pkg/character"
+	"github.com/cfi2017/bl3-save-core/pkg/document"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+	"github.com/cfi2017/bl3-save-core/pkg/profile"
+	"go.starlark.net/starlark"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// method is a method of the save value, calling a helper of the character or profile package.
+type method func(s *save, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error)
+
+var characterMethods = map[string]method{
+	"set_mayhem": func(s *save, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		level, pt := 0, -1
+		if err := starlark.UnpackArgs(b.Name(), args, kwargs, "level", &level, "playthrough?", &pt); err != nil {
+			return nil, err
+		}
+		return starlark.None, s.updateCharacter(func(c *pb.Character) error {
+			if pt < 0 {
+				return character.SetMayhem(c, level)
+			}
+			return character.SetMayhemForPlaythrough(c, pt, level)
+		})
+	},
+	"unlock_playthrough": func(s *save, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		pt := 0
+		if err := starlark.UnpackArgs(b.Name(), args, kwargs, "playthrough", &pt); err != nil {
+			return nil, err
+		}
+		return starlark.None, s.updateCharacter(func(c *pb.Character) error {
+			return character.UnlockPlaythrough(c, pt)
+		})
+	},
+	"playthroughs": func(s *save, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		if err := starlark.UnpackArgs(b.Name(), args, kwargs); err != nil {
+			return nil, err
+		}
+		c := &pb.Character{}
+		if err := s.message(c); err != nil {
+			return nil, err
+		}
+		return starlark.MakeInt(character.Playthroughs(c)), nil
+	},
+	"set_sdu": func(s *save, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var name string
+		var level int
+		if err := starlark.UnpackArgs(b.Name(), args, kwargs, "name", &name, "level", &level); err != nil {
+			return nil, err
+		}
+		return starlark.None, s.updateCharacter(func(c *pb.Character) error {
+			return character.SetSDU(c, name, level)
+		})
+	},
+	"max_sdus": func(s *save, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		if err := starlark.UnpackArgs(b.Name(), args, kwargs); err != nil {
+			return nil, err
+		}
+		return starlark.None, s.updateCharacter(character.MaxSDUs)
+	},
+	"backpack_size": func(s *save, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		if err := starlark.UnpackArgs(b.Name(), args, kwargs); err != nil {
+			return nil, err
+		}
+		c := &pb.Character{}
+		if err := s.message(c); err != nil {
+			return nil, err
+		}
+		return starlark.MakeInt(character.BackpackSize(c)), nil
+	},
+	"unlock_all_slots": func(s *save, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		if err := starlark.UnpackArgs(b.Name(), args, kwargs); err != nil {
+			return nil, err
+		}
+		return starlark.None, s.updateCharacter(character.UnlockAllSlots)
+	},
+	"unlock_all_vehicles": func(s *save, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		if err := starlark.UnpackArgs(b.Name(), args, kwargs); err != nil {
+			return nil, err
+		}
+		return starlark.None, s.updateCharacter(character.UnlockAllVehicles)
+	},
+}
+
+var profileMethods = map[string]method{
+	"set_sdu": func(s *save, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var name string
+		var level int
+		if err := starlark.UnpackArgs(b.Name(), args, kwargs, "name", &name, "level", &level); err != nil {
+			return nil, err
+		}
+		return starlark.None, s.updateProfile(func(p *pb.Profile) error {
+			return profile.SetSDU(p, name, level)
+		})
+	},
+	"max_sdus": func(s *save, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		if err := starlark.UnpackArgs(b.Name(), args, kwargs); err != nil {
+			return nil, err
+		}
+		return starlark.None, s.updateProfile(profile.MaxSDUs)
+	},
+	"bank_size": func(s *save, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		if err := starlark.UnpackArgs(b.Name(), args, kwargs); err != nil {
+			return nil, err
+		}
+		p := &pb.Profile{}
+		if err := s.message(p); err != nil {
+			return nil, err
+		}
+		return starlark.MakeInt(profile.BankSize(p)), nil
+	},
+	"lost_loot_size": func(s *save, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		if err := starlark.UnpackArgs(b.Name(), args, kwargs); err != nil {
+			return nil, err
+		}
+		p := &pb.Profile{}
+		if err := s.message(p); err != nil {
+			return nil, err
+		}
+		return starlark.MakeInt(profile.LostLootSize(p)), nil
+	},
+}
+
+func (s *save) methods() map[string]method {
+	if s.kind == "profile" {
+		return profileMethods
+	}
+	return characterMethods
+}
+
+func (s *save) method(name string) starlark.Value {
+	m, ok := s.methods()[name]
+	if !ok {
+		return nil
+	}
+	return starlark.NewBuiltin(name, func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		v, err := m(s, b, args, kwargs)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", b.Name(), err)
+		}
+		return v, nil
+	})
+}
+
+func (s *save) methodNames() []string {
+	names := make([]string, 0, len(s.methods()))
+	for name := range s.methods() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *save) updateCharacter(change func(c *pb.Character) error) error {
+	c := &pb.Character{}
+	if err := s.message(c); err != nil {
+		return err
+	}
+	if err := change(c); err != nil {
+		return err
+	}
+	return s.setData(c)
+}
+
+func (s *save) updateProfile(change func(p *pb.Profile) error) error {
+	p := &pb.Profile{}
+	if err := s.message(p); err != nil {
+		return err
+	}
+	if err := change(p); err != nil {
+		return err
+	}
+	return s.setData(p)
+}
+
+/*
+setData replaces the content of save.data with the given message. The top level dict is updated in place,
+nested values are replaced, so references to them taken before a method call no longer belong to the save.
+*/
+func (s *save) setData(m proto.Message) error {
+	data, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(m)
+	if err != nil {
+		return err
+	}
+	value, err := fromJSON(data)
+	if err != nil {
+		return err
+	}
+	dict, ok := s.data.(*starlark.Dict)
+	if !ok {
+		return fmt.Errorf("save.data is not a dict")
+	}
+	if err := dict.Clear(); err != nil {
+		return err
+	}
+	for _, item := range value.(*starlark.Dict).Items() {
+		if err := dict.SetKey(item[0], item[1]); err != nil {
+			return err
+		}
+	}
+	s.unknown = document.FindUnknownFields(m)
+	return nil
+}
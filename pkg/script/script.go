@@ -0,0 +1,402 @@
+/*
+Package script runs Starlark scripts that edit saves.
+
+A script sees the save it runs on as the predeclared value save:
+
+	save.type       "character" or "profile"
+	save.data       the save in the JSON form of the document package, as dicts and lists that can be changed in place
+	save.items      the character's backpack or the profile's bank as a list of items
+	save.lost_loot  the profile's lost loot as a list of items
+
+Items have the attributes level, balance, manufacturer, inv_data, parts, generics and flags, which can be changed,
+and rarity, category and serial, which can't. Items are only decoded when save.items or save.lost_loot are used,
+which requires a valid database to be set. If they are used, they replace the inventory lists in save.data.
+
+The save also has methods calling the helpers of the character and profile packages. Characters have:
+
+	save.set_mayhem(level, playthrough=None)  set the mayhem level of one or all playthroughs
+	save.unlock_playthrough(playthrough)      make a zero-based playthrough available
+	save.playthroughs()                       the number of available playthroughs
+	save.set_sdu(name, level)                 set the level of an SDU, by name or path
+	save.max_sdus()                           set all SDUs to their highest level
+	save.backpack_size()                      the number of backpack slots, -1 if unknown
+	save.unlock_all_slots()                   enable all equipment slots
+	save.unlock_all_vehicles()                unlock all vehicle parts and skins of the catalog
+
+Profiles have set_sdu, max_sdus, bank_size() and lost_loot_size(). SDU capacities and highest levels are read from
+the catalog, see assets.Catalog. Methods that change the save replace the values in save.data, references to nested
+values of save.data taken before the call are no longer part of the save.
+
+The bl3 module provides decode_item(serial), which decodes a BL3(...) or base64 serial into a new item.
+
+Scripts run without access to the file system or network, load statements are not supported.
+Loops are limited to for loops, while loops and recursion are disabled. Scripts are stopped after a timeout,
+see Options.
+*/
+package script
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cfi2017/bl3-save-core/pkg/character"
+	"github.com/cfi2017/bl3-save-core/pkg/document"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+	"github.com/cfi2017/bl3-save-core/pkg/profile"
+	"go.starlark.net/resolve"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultTimeout is the time a script may run if Options.Timeout isn't set.
+const DefaultTimeout = 30 * time.Second
+
+// Options control how a script runs.
+type Options struct {
+	// Out receives the output of print, it is discarded if nil.
+	Out io.Writer
+	// Timeout is the time the script may run, DefaultTimeout if zero.
+	Timeout time.Duration
+}
+
+// resolveMu serializes changes to the global flags of the resolve package.
+var resolveMu sync.Mutex
+
+/*
+compile parses and resolves a script. Scripts are small programs rather than configuration,
+so top level statements, floats, sets, lambdas and nested functions are allowed. while loops and recursion stay disabled.
+
+The resolve package only has process wide flags for these options, they are enabled while the script is resolved and
+restored afterwards. Other code resolving Starlark at the same time sees them enabled.
+*/
+func compile(filename string, src []byte, predeclared starlark.StringDict) (*starlark.Program, error) {
+	resolveMu.Lock()
+	defer resolveMu.Unlock()
+	nestedDef, lambda, float, set, globalReassign := resolve.AllowNestedDef, resolve.AllowLambda, resolve.AllowFloat,
+		resolve.AllowSet, resolve.AllowGlobalReassign
+	defer func() {
+		resolve.AllowNestedDef, resolve.AllowLambda, resolve.AllowFloat, resolve.AllowSet, resolve.AllowGlobalReassign =
+			nestedDef, lambda, float, set, globalReassign
+	}()
+	resolve.AllowNestedDef, resolve.AllowLambda, resolve.AllowFloat, resolve.AllowSet, resolve.AllowGlobalReassign =
+		true, true, true, true, true
+	_, prog, err := starlark.SourceProgram(filename, src, predeclared.Has)
+	return prog, err
+}
+
+/*
+RunCharacter runs a script against a character. The character is only changed if the script succeeds.
+*/
+func RunCharacter(filename string, src []byte, c *pb.Character, opts Options) error {
+	s, err := newSave("character", c)
+	if err != nil {
+		return err
+	}
+	// the script may outlive the call if it times out, it must not read the character after that
+	serials, flags := make([][]byte, len(c.InventoryItems)), make([]int32, len(c.InventoryItems))
+	for i, data := range c.InventoryItems {
+		serials[i], flags[i] = data.ItemSerialNumber, data.Flags
+	}
+	s.items = &itemList{name: "items", load: func() ([][]byte, []int32) { return serials, flags }}
+	if err := s.run(filename, src, opts); err != nil {
+		return err
+	}
+	result := &pb.Character{}
+	if err := s.message(result); err != nil {
+		return err
+	}
+	if s.items.list != nil {
+		if err := applyInventory(result, c, s.items); err != nil {
+			return err
+		}
+	}
+	proto.Reset(c)
+	proto.Merge(c, result)
+	return nil
+}
+
+/*
+RunProfile runs a script against a profile. The profile is only changed if the script succeeds.
+*/
+func RunProfile(filename string, src []byte, p *pb.Profile, opts Options) error {
+	s, err := newSave("profile", p)
+	if err != nil {
+		return err
+	}
+	bank, lostLoot := p.BankInventoryList, p.LostLootInventoryList
+	s.items = &itemList{name: "items", load: func() ([][]byte, []int32) { return bank, nil }}
+	s.lostLoot = &itemList{name: "lost_loot", load: func() ([][]byte, []int32) { return lostLoot, nil }}
+	if err := s.run(filename, src, opts); err != nil {
+		return err
+	}
+	result := &pb.Profile{}
+	if err := s.message(result); err != nil {
+		return err
+	}
+	if s.items.list != nil {
+		if result.BankInventoryList, err = s.items.serials(); err != nil {
+			return err
+		}
+	}
+	if s.lostLoot.list != nil {
+		if result.LostLootInventoryList, err = s.lostLoot.serials(); err != nil {
+			return err
+		}
+	}
+	proto.Reset(p)
+	proto.Merge(p, result)
+	return nil
+}
+
+// save is the value of the predeclared save variable.
+type save struct {
+	kind     string
+	data     starlark.Value
+	unknown  []document.UnknownField
+	items    *itemList
+	lostLoot *itemList
+}
+
+func newSave(kind string, m proto.Message) (*save, error) {
+	data, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	value, err := fromJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	return &save{kind: kind, data: value, unknown: document.FindUnknownFields(m)}, nil
+}
+
+/*
+run executes the script. The Starlark interpreter can't interrupt a running script, a script that times out keeps
+running in the background until it finishes, but its output is dropped and its changes are never applied.
+*/
+func (s *save) run(filename string, src []byte, opts Options) error {
+	out, timeout := opts.Out, opts.Timeout
+	if out == nil {
+		out = ioutil.Discard
+	}
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	var mu sync.Mutex
+	stopped := false
+	thread := &starlark.Thread{
+		Name: filename,
+		Print: func(_ *starlark.Thread, msg string) {
+			mu.Lock()
+			defer mu.Unlock()
+			if !stopped {
+				fmt.Fprintln(out, msg)
+			}
+		},
+		// no Load, scripts can't read other files
+	}
+	predeclared := starlark.StringDict{
+		"save": s,
+		"bl3": &starlarkstruct.Module{Name: "bl3", Members: starlark.StringDict{
+			"decode_item": starlark.NewBuiltin("decode_item", decodeItem),
+		}},
+	}
+	prog, err := compile(filename, src, predeclared)
+	if err != nil {
+		return err
+	}
+	done := make(chan error, 1)
+	go func() {
+		_, err := prog.Init(thread, predeclared)
+		done <- err
+	}()
+	select {
+	case err = <-done:
+	case <-time.After(timeout):
+		mu.Lock()
+		stopped = true
+		mu.Unlock()
+		return fmt.Errorf("script timed out after %v", timeout)
+	}
+	if evalErr, ok := err.(*starlark.EvalError); ok {
+		return fmt.Errorf("%s", evalErr.Backtrace())
+	}
+	return err
+}
+
+/*
+message converts save.data back into a message, keeping unknown fields.
+Unknown fields of messages the script removed are dropped with them.
+*/
+func (s *save) message(m proto.Message) error {
+	data, err := toJSON(s.data)
+	if err != nil {
+		return fmt.Errorf("invalid save.data: %v", err)
+	}
+	if err := protojson.Unmarshal(data, m); err != nil {
+		return fmt.Errorf("invalid save.data: %v", err)
+	}
+	var missing *document.MissingFieldsError
+	if err := document.RestoreUnknownFields(m, s.unknown); err != nil && !errors.As(err, &missing) {
+		return err
+	}
+	return nil
+}
+
+func (s *save) String() string        { return fmt.Sprintf("<%s save>", s.kind) }
+func (s *save) Type() string          { return "save" }
+func (s *save) Freeze()               {}
+func (s *save) Truth() starlark.Bool  { return true }
+func (s *save) Hash() (uint32, error) { return 0, fmt.Errorf("unhashable type: save") }
+
+func (s *save) Attr(name string) (starlark.Value, error) {
+	switch name {
+	case "type":
+		return starlark.String(s.kind), nil
+	case "data":
+		return s.data, nil
+	case "items":
+		return s.items.get()
+	case "lost_loot":
+		if s.lostLoot != nil {
+			return s.lostLoot.get()
+		}
+	}
+	return s.method(name), nil
+}
+
+func (s *save) AttrNames() []string {
+	names := []string{"data", "items", "type"}
+	if s.lostLoot != nil {
+		names = append(names, "lost_loot")
+	}
+	names = append(names, s.methodNames()...)
+	sort.Strings(names)
+	return names
+}
+
+// itemList is a list of items that is decoded on first use. load returns the serials and, for inventories, the item flags.
+type itemList struct {
+	name string
+	load func() ([][]byte, []int32)
+	list *starlark.List
+}
+
+func (l *itemList) get() (starlark.Value, error) {
+	if l.list != nil {
+		return l.list, nil
+	}
+	serials, flags := l.load()
+	values := make([]starlark.Value, len(serials))
+	for i, serial := range serials {
+		v, err := newItem(serial, i)
+		if err != nil {
+			return nil, fmt.Errorf("%s[%d]: %v", l.name, i, err)
+		}
+		if flags != nil {
+			v.flags = flags[i]
+		}
+		values[i] = v
+	}
+	l.list = starlark.NewList(values)
+	return l.list, nil
+}
+
+// values returns the items of the list after the script ran.
+func (l *itemList) values() ([]*itemValue, error) {
+	values := make([]*itemValue, l.list.Len())
+	for i := range values {
+		v, ok := l.list.Index(i).(*itemValue)
+		if !ok {
+			return nil, fmt.Errorf("%s[%d]: expected item, got %s", l.name, i, l.list.Index(i).Type())
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+func (l *itemList) serials() ([][]byte, error) {
+	values, err := l.values()
+	if err != nil {
+		return nil, err
+	}
+	serials := make([][]byte, len(values))
+	for i, v := range values {
+		if serials[i], err = v.serial(); err != nil {
+			return nil, fmt.Errorf("%s[%d]: %v", l.name, i, err)
+		}
+	}
+	return serials, nil
+}
+
+/*
+applyInventory writes the script's item list into the character's inventory.
+Entries keep their pickup order, equipped items that were removed are unequipped.
+*/
+func applyInventory(c, original *pb.Character, items *itemList) error {
+	values, err := items.values()
+	if err != nil {
+		return err
+	}
+	next := int32(0)
+	for _, data := range original.InventoryItems {
+		if data.PickupOrderIndex >= next {
+			next = data.PickupOrderIndex + 1
+		}
+	}
+	indexes := map[int]int{}
+	list := make([]*pb.OakInventoryItemSaveGameData, len(values))
+	for i, v := range values {
+		serial, err := v.serial()
+		if err != nil {
+			return fmt.Errorf("items[%d]: %v", i, err)
+		}
+		var entry *pb.OakInventoryItemSaveGameData
+		if _, ok := indexes[v.index]; !ok && v.index >= 0 && v.index < len(original.InventoryItems) {
+			indexes[v.index] = i
+			entry = proto.Clone(original.InventoryItems[v.index]).(*pb.OakInventoryItemSaveGameData)
+		} else {
+			// new and duplicated items are the most recently picked up
+			entry = &pb.OakInventoryItemSaveGameData{PickupOrderIndex: next}
+			next++
+		}
+		entry.ItemSerialNumber, entry.Flags = serial, v.flags
+		list[i] = entry
+	}
+	c.InventoryItems = list
+	for _, e := range c.EquippedInventoryList {
+		if index, ok := indexes[int(e.InventoryListIndex)]; ok {
+			e.InventoryListIndex = int32(index)
+		} else {
+			// -1 marks an empty slot
+			e.InventoryListIndex = -1
+		}
+	}
+	character.UpdateActiveWeapons(c)
+	return nil
+}
+
+func decodeItem(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var code string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "serial", &code); err != nil {
+		return nil, err
+	}
+	serial, err := profile.DecodeMailSerial(code)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", b.Name(), err)
+	}
+	v, err := newItem(serial, -1)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", b.Name(), err)
+	}
+	if v.item.Balance == "" {
+		return nil, fmt.Errorf("%s: couldn't decode item", b.Name())
+	}
+	return v, nil
+}
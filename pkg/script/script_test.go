@@ -0,0 +1,103 @@
+package script
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cfi2017/bl3-save-core/internal/testassets"
+	"github.com/cfi2017/bl3-save-core/pkg/pb"
+	"go.starlark.net/resolve"
+)
+
+func TestRunCharacter(t *testing.T) {
+	c := &pb.Character{
+		PreferredCharacterName: "Zane",
+		SduList: []*pb.OakSDUSaveGameData{
+			{SduLevel: 1, SduDataPath: "/Game/Pawns/SDU/SDU_Backpack.SDU_Backpack"},
+			{SduLevel: 2, SduDataPath: "/Game/Pawns/SDU/SDU_Pistol.SDU_Pistol"},
+		},
+	}
+	c.ProtoReflect().SetUnknown([]byte{0xa0, 0x38, 0x01})
+	src := `
+save.data["mayhemLevel"] = 10
+for sdu in save.data["sduList"]:
+    sdu["sduLevel"] = 10
+print(save.type, save.data["preferredCharacterName"])
+`
+	out := new(bytes.Buffer)
+	if err := RunCharacter("edit.star", []byte(src), c, Options{Out: out}); err != nil {
+		t.Fatal(err)
+	}
+	if c.MayhemLevel != 10 || c.SduList[0].SduLevel != 10 || c.SduList[1].SduLevel != 10 {
+		t.Fatalf("script not applied: %v", c)
+	}
+	if out.String() != "character Zane\n" {
+		t.Errorf("unexpected output %q", out.String())
+	}
+	if len(c.ProtoReflect().GetUnknown()) == 0 {
+		t.Error("unknown fields lost")
+	}
+
+	for _, src := range []string{
+		`save.data["mayhemLevel"] = 3` + "\nfail('stop')",
+		`load("other.star", "x")`,
+		`save.data["mayhemLvl"] = 3`,
+	} {
+		if err := RunCharacter("edit.star", []byte(src), c, Options{Out: out}); err == nil {
+			t.Errorf("expected error running %q", src)
+		}
+	}
+	if c.MayhemLevel != 10 {
+		t.Error("failed script changed the character")
+	}
+}
+
+func TestMethods(t *testing.T) {
+	defer testassets.Catalog()()
+	c := &pb.Character{PlaythroughsCompleted: 1}
+	src := `
+save.unlock_playthrough(1)
+save.set_mayhem(4)
+save.set_mayhem(10, playthrough=1)
+save.set_sdu("Backpack", 3)
+print(save.playthroughs(), save.backpack_size())
+`
+	out := new(bytes.Buffer)
+	if err := RunCharacter("methods.star", []byte(src), c, Options{Out: out}); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.GameStateSaveDataForPlaythrough) != 2 || c.GameStateSaveDataForPlaythrough[0].MayhemLevel != 4 ||
+		c.GameStateSaveDataForPlaythrough[1].MayhemLevel != 10 || c.MayhemLevel != 4 {
+		t.Fatalf("mayhem not set: %v", c.GameStateSaveDataForPlaythrough)
+	}
+	if out.String() != "2 24\n" {
+		t.Errorf("unexpected output %q", out.String())
+	}
+	if err := RunCharacter("methods.star", []byte(`save.set_sdu("Bank", 1)`), c, Options{Out: out}); err == nil {
+		t.Error("expected error setting a profile SDU on a character")
+	}
+
+	p := &pb.Profile{}
+	if err := RunProfile("methods.star", []byte("save.max_sdus()\nprint(save.bank_size())"), p, Options{Out: out}); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.ProfileSduList) != 2 || !bytes.HasSuffix(out.Bytes(), []byte("158\n")) {
+		t.Fatalf("profile SDUs not maxed: %v", p.ProfileSduList)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	c := &pb.Character{}
+	err := RunCharacter("loop.star", []byte("for i in range(1 << 30):\n    for j in range(1 << 30):\n        pass"), c, Options{Timeout: 50 * time.Millisecond})
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected timeout, got %v", err)
+	}
+	if err := RunCharacter("while.star", []byte("def f():\n    while True:\n        pass"), c, Options{}); err == nil {
+		t.Error("expected error for while loop")
+	}
+	if resolve.AllowGlobalReassign || resolve.AllowFloat {
+		t.Error("resolve flags not restored")
+	}
+}
@@ -43,7 +43,7 @@ func WriteGuid(w io.Writer, guid string) {
 
 func ReadString(r io.Reader) string {
 	l := ReadInt(r)
-	if l <= 1 {
+	if l == 0 {
 		return ""
 	}
 	bs := ReadNBytes(r, l)
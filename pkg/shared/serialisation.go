@@ -66,4 +66,7 @@ func SerializeHeader(writer io.Writer, s SavFile, content []byte) {
 
 	WriteInt(w, len(content))
 	WriteBytes(w, content)
+	if err := w.Flush(); err != nil {
+		panic(err)
+	}
 }
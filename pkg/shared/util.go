@@ -35,3 +35,15 @@ func OpenBrowser(url string) {
 	}
 
 }
+
+/*
+Catch runs f and converts panics, as raised by the save and item packages for malformed data, into errors.
+*/
+func Catch(f func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return f()
+}
@@ -0,0 +1,203 @@
+package shared
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// VerifyStatus is the outcome of a round trip verification.
+type VerifyStatus int
+
+const (
+	// Identical means the re-encoded save is byte for byte the same as the original.
+	Identical VerifyStatus = iota
+	// Equivalent means the bytes differ, but the re-encoded save decodes to the same header and data.
+	Equivalent
+	// Different means the re-encoded save contains different data.
+	Different
+)
+
+func (s VerifyStatus) String() string {
+	switch s {
+	case Identical:
+		return "identical"
+	case Equivalent:
+		return "equivalent"
+	case Different:
+		return "different"
+	}
+	return fmt.Sprintf("VerifyStatus(%d)", int(s))
+}
+
+// VerifyResult describes how a save changed when it was decoded and encoded again.
+type VerifyResult struct {
+	Status VerifyStatus
+	// Offset of the first byte that differs between the original and the re-encoded file, -1 if they are identical.
+	Offset int
+	// Size of the original and the re-encoded file.
+	Size, EncodedSize int
+	// Header fields whose value changed.
+	Header []string
+	// Top level fields of the save data whose encoding changed.
+	Fields []FieldChange
+}
+
+// FieldChange is a top level protobuf field of a save whose encoding changed.
+type FieldChange struct {
+	Name   string
+	Number int32
+	// Offset of the field in the original decrypted save data, -1 if the field was added.
+	// Fields are compared by their encoding and by their position among the fields of the save.
+	Offset int
+	// Equal is set if the field still holds the same value, e.g. if only the order of its encoding changed.
+	Equal bool
+}
+
+/*
+Verify decodes a save and encodes it again without changes, then compares the result to the original.
+m is an empty message of the save's type, it holds the decoded save afterwards.
+*/
+func Verify(data []byte, magic PlatformMagic, m proto.Message) (result VerifyResult, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	s, payload := DeserializeHeader(bytes.NewReader(data))
+	plain := Decrypt(append([]byte{}, payload...), magic.Prefix, magic.Xor)
+	if err := proto.Unmarshal(plain, m); err != nil {
+		return result, err
+	}
+
+	encodedPlain, err := proto.Marshal(m)
+	if err != nil {
+		return result, err
+	}
+	buf := new(bytes.Buffer)
+	SerializeHeader(buf, s, Encrypt(append([]byte{}, encodedPlain...), magic.Prefix, magic.Xor))
+	encoded := buf.Bytes()
+
+	result = VerifyResult{Offset: firstDifference(data, encoded), Size: len(data), EncodedSize: len(encoded)}
+	if result.Offset < 0 {
+		return result, nil
+	}
+
+	s2, _ := DeserializeHeader(bytes.NewReader(encoded))
+	result.Header = headerChanges(s, s2)
+	m2 := m.ProtoReflect().New().Interface()
+	if err := proto.Unmarshal(encodedPlain, m2); err != nil {
+		return result, err
+	}
+	result.Fields, err = fieldChanges(plain, encodedPlain, m, m2)
+	if err != nil {
+		return result, err
+	}
+	result.Status = Equivalent
+	if len(result.Header) > 0 || !proto.Equal(m, m2) {
+		result.Status = Different
+	}
+	return result, nil
+}
+
+func firstDifference(a, b []byte) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return len(a)
+		}
+		return len(b)
+	}
+	return -1
+}
+
+func headerChanges(a, b SavFile) []string {
+	var changes []string
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	for i := 0; i < va.NumField(); i++ {
+		if !reflect.DeepEqual(va.Field(i).Interface(), vb.Field(i).Interface()) {
+			changes = append(changes, va.Type().Field(i).Name)
+		}
+	}
+	return changes
+}
+
+// fieldChanges compares the encoding of the top level fields of two messages.
+func fieldChanges(original, encoded []byte, m, m2 proto.Message) ([]FieldChange, error) {
+	a, err := groupWireFields(original)
+	if err != nil {
+		return nil, err
+	}
+	b, err := groupWireFields(encoded)
+	if err != nil {
+		return nil, err
+	}
+	position := map[int32]int{}
+	for i, number := range b.order {
+		position[number] = i
+	}
+	var changes []FieldChange
+	for i, number := range a.order {
+		// fields are reported if their encoding or their position changed
+		if !bytes.Equal(a.data[number], b.data[number]) || position[number] != i {
+			changes = append(changes, fieldChange(number, a.offsets[number], m, m2))
+		}
+	}
+	for _, number := range b.order {
+		if _, ok := a.data[number]; !ok {
+			changes = append(changes, fieldChange(number, -1, m, m2))
+		}
+	}
+	return changes, nil
+}
+
+func fieldChange(number int32, offset int, m, m2 proto.Message) FieldChange {
+	change := FieldChange{Name: fmt.Sprintf("unknown field %d", number), Number: number, Offset: offset}
+	fd := m.ProtoReflect().Descriptor().Fields().ByNumber(protoreflect.FieldNumber(number))
+	if fd == nil {
+		return change
+	}
+	change.Name = string(fd.Name())
+	// compare copies that only contain this field
+	a, b := m.ProtoReflect().New(), m2.ProtoReflect().New()
+	if m.ProtoReflect().Has(fd) {
+		a.Set(fd, m.ProtoReflect().Get(fd))
+	}
+	if m2.ProtoReflect().Has(fd) {
+		b.Set(fd, m2.ProtoReflect().Get(fd))
+	}
+	change.Equal = proto.Equal(a.Interface(), b.Interface())
+	return change
+}
+
+// wireFieldGroups holds the concatenated encoding of every field number of a message.
+type wireFieldGroups struct {
+	order   []int32
+	data    map[int32][]byte
+	offsets map[int32]int
+}
+
+func groupWireFields(data []byte) (wireFieldGroups, error) {
+	groups := wireFieldGroups{data: map[int32][]byte{}, offsets: map[int32]int{}}
+	fields, err := SplitWireFields(data)
+	if err != nil {
+		return groups, err
+	}
+	offset := 0
+	for _, f := range fields {
+		if _, ok := groups.data[f.Number]; !ok {
+			groups.order = append(groups.order, f.Number)
+			groups.offsets[f.Number] = offset
+		}
+		groups.data[f.Number] = append(groups.data[f.Number], f.Data...)
+		offset += len(f.Data)
+	}
+	return groups, nil
+}
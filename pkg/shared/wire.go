@@ -0,0 +1,56 @@
+package shared
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// WireField is a single field of an encoded protobuf message, Data includes the field's tag.
+type WireField struct {
+	Number int32
+	Data   []byte
+}
+
+/*
+SplitWireFields splits an encoded protobuf message into its top level fields, in the order they are encoded.
+Groups are deprecated and don't occur in saves, their start and end tags are returned as separate fields.
+*/
+func SplitWireFields(data []byte) ([]WireField, error) {
+	var fields []WireField
+	for offset := 0; offset < len(data); {
+		tag, n := binary.Uvarint(data[offset:])
+		if n <= 0 {
+			return fields, fmt.Errorf("invalid field tag at offset %d", offset)
+		}
+		number, wireType := int32(tag>>3), tag&7
+		var size int
+		switch wireType {
+		case 0:
+			if _, size = binary.Uvarint(data[offset+n:]); size == 0 {
+				size = -1
+			}
+		case 1:
+			size = 8
+		case 2:
+			var length uint64
+			length, size = binary.Uvarint(data[offset+n:])
+			if size == 0 {
+				size = -1
+			} else if size > 0 {
+				size += int(length)
+			}
+		case 3, 4:
+			size = 0
+		case 5:
+			size = 4
+		default:
+			return fields, fmt.Errorf("invalid wire type %d at offset %d", wireType, offset)
+		}
+		if size < 0 || size > len(data)-offset-n {
+			return fields, fmt.Errorf("field %d at offset %d is truncated", number, offset)
+		}
+		fields = append(fields, WireField{Number: number, Data: data[offset : offset+n+size]})
+		offset += n + size
+	}
+	return fields, nil
+}